@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments [issue-key]",
+	Short: "List or download the attachments of an issue",
+	Long: `List the attachments of an issue with their filename, size, MIME type, author and
+creation date.
+
+With --download, every attachment is streamed into the given directory through the
+authenticated client. Downloads larger than --max-size are rejected and each file's size
+is checked against the Jira metadata. The SHA-256 checksum of each file is recorded in a
+SHA256SUMS manifest next to the files. When the directory already has a SHA256SUMS from an
+earlier download, each file listed in it must match its recorded checksum or the download
+fails and the existing file is kept.
+
+Examples:
+  jiracrawler get attachments CNF-1234 -o table
+  jiracrawler get attachments CNF-1234 --download ./logs/CNF-1234`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		dir, _ := cmd.Flags().GetString("download")
+		maxSize, _ := cmd.Flags().GetInt64("max-size")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		result, err := lib.FetchIssueAttachments(jiraURL, apikey, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if dir != "" {
+			if err := lib.DownloadAttachments(jiraURL, apikey, result, dir, maxSize); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+		}
+
+		if err := printOutput(output, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(attachmentsCmd)
+
+	addOutputFlag(attachmentsCmd, "json", "json", "yaml", "table")
+	attachmentsCmd.Flags().StringP("download", "d", "", "Directory to download the attachments into")
+	attachmentsCmd.Flags().Int64("max-size", lib.DefaultMaxAttachmentSize, "Maximum size in bytes of a single attachment")
+}
@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAttachmentsCmdStructure(t *testing.T) {
+	assert.Equal(t, "attachments [issue-key]", attachmentsCmd.Use)
+
+	downloadFlag := attachmentsCmd.Flags().Lookup("download")
+	assert.NotNil(t, downloadFlag)
+	assert.Equal(t, "d", downloadFlag.Shorthand)
+
+	maxSizeFlag := attachmentsCmd.Flags().Lookup("max-size")
+	assert.NotNil(t, maxSizeFlag)
+	assert.Equal(t, "104857600", maxSizeFlag.DefValue)
+}
+
+func TestAttachmentsCmdRegistered(t *testing.T) {
+	names := make([]string, 0)
+	for _, cmd := range getCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "attachments")
+}
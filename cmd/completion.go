@@ -2,10 +2,25 @@ package cmd
 
 import (
 	"os"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/sebrandon1/jiracrawler/lib"
 	"github.com/spf13/cobra"
 )
 
+const (
+	// completionCacheTTL is how long cached completion values are used before being refreshed
+	completionCacheTTL = time.Hour
+
+	// completionFetchTimeout bounds the time spent refreshing completion values from Jira
+	completionFetchTimeout = 2 * time.Second
+
+	// recentAssigneeDays is how far back completion looks for assignees of a project
+	recentAssigneeDays = 30
+)
+
 var completionCmd = &cobra.Command{
 	Use:   "completion [bash|zsh|fish|powershell]",
 	Short: "Generate shell completion scripts",
@@ -54,3 +69,183 @@ PowerShell:
 func init() {
 	rootCmd.AddCommand(completionCmd)
 }
+
+// statusFlags are the flags whose values are workflow statuses
+var statusFlags = []string{"blocked-status", "workflow"}
+
+// registerCompletions adds dynamic completion for project keys, teams, users and statuses to
+// every command below root. It runs after all commands are registered.
+func registerCompletions(root *cobra.Command) {
+	for _, c := range root.Commands() {
+		registerCompletions(c)
+	}
+
+	if root.PersistentFlags().Lookup("projectID") != nil {
+		_ = root.RegisterFlagCompletionFunc("projectID", completeProjectKeys)
+	}
+	if root.Flags().Lookup("team") != nil {
+		_ = root.RegisterFlagCompletionFunc("team", completeTeamNames)
+	}
+	for _, name := range statusFlags {
+		if root.Flags().Lookup(name) != nil {
+			_ = root.RegisterFlagCompletionFunc(name, completeStatuses)
+		}
+	}
+}
+
+// addOutputFlag registers the -o/--output flag of a command with the formats it supports,
+// which are listed in its usage and offered by shell completion
+func addOutputFlag(cmd *cobra.Command, defaultFormat string, formats ...string) {
+	cmd.Flags().StringP("output", "o", defaultFormat, "Output format: "+strings.Join(formats, "|"))
+	_ = cmd.RegisterFlagCompletionFunc("output", cobra.FixedCompletions(formats, cobra.ShellCompDirectiveNoFileComp))
+}
+
+// cachedCompletion loads a cache entry, refreshing it with fetch when it is older than
+// completionCacheTTL. If Jira cannot be reached in time, a stale entry is used instead.
+func cachedCompletion(name string, v interface{}, fetch func(jiraURL, apikey string) (interface{}, error)) bool {
+	jiraURL := GetConfigValue("jira_url")
+	apikey := GetConfigValue("apikey")
+	if jiraURL == "" {
+		return false
+	}
+	if lib.LoadCache(jiraURL, name, completionCacheTTL, v) {
+		return true
+	}
+
+	if apikey != "" {
+		if result, ok := fetchWithTimeout(jiraURL, apikey, fetch); ok {
+			_ = lib.SaveCache(jiraURL, name, result)
+			return lib.LoadCache(jiraURL, name, 0, v)
+		}
+	}
+	return lib.LoadCache(jiraURL, name, 0, v)
+}
+
+// fetchWithTimeout runs fetch, giving up after completionFetchTimeout so completion stays responsive
+func fetchWithTimeout(jiraURL, apikey string, fetch func(jiraURL, apikey string) (interface{}, error)) (interface{}, bool) {
+	type fetchResult struct {
+		value interface{}
+		err   error
+	}
+	done := make(chan fetchResult, 1)
+	go func() {
+		value, err := fetch(jiraURL, apikey)
+		done <- fetchResult{value, err}
+	}()
+
+	select {
+	case r := <-done:
+		return r.value, r.err == nil
+	case <-time.After(completionFetchTimeout):
+		return nil, false
+	}
+}
+
+// completeProjectKeys completes project keys from the cached project list
+func completeProjectKeys(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	var projects lib.ProjectListResult
+	ok := cachedCompletion(lib.ProjectsCacheName, &projects, func(jiraURL, apikey string) (interface{}, error) {
+		return lib.FetchProjects(jiraURL, apikey)
+	})
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	var keys []string
+	for _, p := range projects.Projects {
+		if strings.HasPrefix(strings.ToUpper(p.Key), strings.ToUpper(toComplete)) {
+			keys = append(keys, p.Key+"\t"+p.Name)
+		}
+	}
+	return keys, cobra.ShellCompDirectiveNoFileComp
+}
+
+// completeProjectKeyArg completes a single project key argument
+func completeProjectKeyArg(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	if len(args) > 0 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return completeProjectKeys(cmd, args, toComplete)
+}
+
+// completeAssignees completes usernames from the recent assignees of the selected project,
+// falling back to the user search API when no recent assignee matches
+func completeAssignees(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectID, _ := cmd.Flags().GetString("projectID")
+	if projectID == "" {
+		projectID = "CNF"
+	}
+
+	var users []lib.User
+	cachedCompletion(lib.RecentAssigneesCacheName(projectID), &users, func(jiraURL, apikey string) (interface{}, error) {
+		return lib.FetchRecentAssignees(jiraURL, apikey, projectID, recentAssigneeDays)
+	})
+
+	candidates := userCompletions(users, toComplete, args)
+	jiraURL, apikey := GetConfigValue("jira_url"), GetConfigValue("apikey")
+	if len(candidates) == 0 && len(toComplete) >= 2 && jiraURL != "" && apikey != "" {
+		// Searches depend on what was typed, so they are not cached
+		found, ok := fetchWithTimeout(jiraURL, apikey, func(jiraURL, apikey string) (interface{}, error) {
+			return lib.SearchUsers(jiraURL, apikey, toComplete, 20)
+		})
+		if users, isUsers := found.([]lib.User); ok && isUsers {
+			candidates = userCompletions(users, toComplete, args)
+		}
+	}
+	return candidates, cobra.ShellCompDirectiveNoFileComp
+}
+
+// userCompletions returns the usernames starting with toComplete that are not already in args
+func userCompletions(users []lib.User, toComplete string, args []string) []string {
+	var candidates []string
+	for _, u := range users {
+		if u.Name == "" || slices.Contains(args, u.Name) {
+			continue
+		}
+		if strings.HasPrefix(strings.ToLower(u.Name), strings.ToLower(toComplete)) {
+			candidates = append(candidates, u.Name+"\t"+u.DisplayName)
+		}
+	}
+	return candidates
+}
+
+// completeStatuses completes workflow statuses from the cached metadata of the selected
+// project, which "project info" also writes. The project is taken from --projectID, then
+// from the default project of --team. Statuses of a comma-separated list are completed
+// one at a time.
+func completeStatuses(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	projectID := "CNF"
+	if f := cmd.Flags().Lookup("projectID"); f != nil {
+		projectID = f.Value.String()
+	} else if teamName, _ := cmd.Flags().GetString("team"); teamName != "" {
+		if teams, err := loadTeams(); err == nil && teams[strings.ToLower(teamName)].Project != "" {
+			projectID = teams[strings.ToLower(teamName)].Project
+		}
+	}
+
+	var info lib.ProjectInfo
+	ok := cachedCompletion(lib.ProjectCacheName(projectID), &info, func(jiraURL, apikey string) (interface{}, error) {
+		return lib.FetchProjectInfo(jiraURL, apikey, projectID)
+	})
+	if !ok {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	return statusCompletions(info.Statuses(), toComplete), cobra.ShellCompDirectiveNoFileComp
+}
+
+// statusCompletions returns the statuses starting with the last comma-separated part of
+// toComplete, keeping the parts before it
+func statusCompletions(statuses []string, toComplete string) []string {
+	prefix, partial := "", toComplete
+	if i := strings.LastIndex(toComplete, ","); i >= 0 {
+		prefix, partial = toComplete[:i+1], toComplete[i+1:]
+	}
+
+	var candidates []string
+	for _, status := range statuses {
+		if strings.HasPrefix(strings.ToLower(status), strings.ToLower(partial)) {
+			candidates = append(candidates, prefix+status)
+		}
+	}
+	return candidates
+}
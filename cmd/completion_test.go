@@ -3,6 +3,9 @@ package cmd
 import (
 	"testing"
 
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -12,3 +15,88 @@ func TestCompletionCmdStructure(t *testing.T) {
 	assert.Equal(t, []string{"bash", "zsh", "fish", "powershell"}, completionCmd.ValidArgs)
 	assert.True(t, completionCmd.DisableFlagsInUseLine)
 }
+
+func TestAddOutputFlag(t *testing.T) {
+	cmd := &cobra.Command{Use: "test"}
+	addOutputFlag(cmd, "markdown", "json", "markdown")
+
+	flag := cmd.Flags().Lookup("output")
+	assert.Equal(t, "o", flag.Shorthand)
+	assert.Equal(t, "markdown", flag.DefValue)
+	assert.Equal(t, "Output format: json|markdown", flag.Usage)
+
+	complete, ok := cmd.GetFlagCompletionFunc("output")
+	assert.True(t, ok)
+	formats, _ := complete(cmd, nil, "")
+	assert.Equal(t, []string{"json", "markdown"}, formats)
+}
+
+func TestRegisterCompletions(t *testing.T) {
+	registerCompletions(rootCmd)
+	registerCompletions(getCmd)
+
+	_, ok := queryCmd.GetFlagCompletionFunc("output")
+	assert.True(t, ok)
+	_, ok = assignedIssuesCmd.GetFlagCompletionFunc("projectID")
+	assert.True(t, ok)
+	assert.NotNil(t, assignedIssuesCmd.ValidArgsFunction)
+	assert.NotNil(t, projectInfoCmd.ValidArgsFunction)
+}
+
+func TestCompleteProjectKeys_FromCache(t *testing.T) {
+	lib.SetCacheDir(t.TempDir())
+	defer lib.SetCacheDir("")
+	viper.Set("jira_url", "https://issues.example.com")
+	viper.Set("apikey", "")
+	defer viper.Set("jira_url", "")
+
+	projects := &lib.ProjectListResult{Projects: []lib.Project{
+		{Key: "CNF", Name: "Cloud Native Functions"},
+		{Key: "OCPBUGS", Name: "OpenShift Bugs"},
+	}}
+	assert.NoError(t, lib.SaveCache("https://issues.example.com", lib.ProjectsCacheName, projects))
+
+	keys, directive := completeProjectKeys(assignedIssuesCmd, nil, "cn")
+	assert.Equal(t, []string{"CNF\tCloud Native Functions"}, keys)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestUserCompletions(t *testing.T) {
+	users := []lib.User{
+		{Name: "jdoe", DisplayName: "Jane Doe"},
+		{Name: "jsmith", DisplayName: "John Smith"},
+		{Name: "alee", DisplayName: "Alex Lee"},
+	}
+	assert.Equal(t, []string{"jsmith\tJohn Smith"}, userCompletions(users, "j", []string{"jdoe"}))
+	assert.Empty(t, userCompletions(users, "x", nil))
+}
+
+func TestCompleteStatuses_FromProjectCache(t *testing.T) {
+	lib.SetCacheDir(t.TempDir())
+	defer lib.SetCacheDir("")
+	viper.Set("jira_url", "https://issues.example.com")
+	viper.Set("apikey", "")
+	defer viper.Set("jira_url", "")
+
+	info := &lib.ProjectInfo{IssueTypes: []lib.IssueTypeStatuses{
+		{IssueType: lib.IssueType{Name: "Bug"}, Statuses: []lib.WorkflowStatus{{Name: "New"}, {Name: "Blocked"}, {Name: "Done"}}},
+	}}
+	assert.NoError(t, lib.SaveCache("https://issues.example.com", lib.ProjectCacheName("CNF"), info))
+
+	registerCompletions(reportCmd)
+	_, ok := standupReportCmd.GetFlagCompletionFunc("blocked-status")
+	assert.True(t, ok)
+	_, ok = churnReportCmd.GetFlagCompletionFunc("workflow")
+	assert.True(t, ok)
+
+	statuses, directive := completeStatuses(churnReportCmd, nil, "b")
+	assert.Equal(t, []string{"Blocked"}, statuses)
+	assert.Equal(t, cobra.ShellCompDirectiveNoFileComp, directive)
+}
+
+func TestStatusCompletions(t *testing.T) {
+	statuses := []string{"New", "In Progress", "Done"}
+	assert.Equal(t, []string{"In Progress"}, statusCompletions(statuses, "in"))
+	assert.Equal(t, []string{"New,Done"}, statusCompletions(statuses, "New,d"))
+	assert.Len(t, statusCompletions(statuses, ""), 3)
+}
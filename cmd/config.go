@@ -3,7 +3,10 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/sebrandon1/jiracrawler/lib"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -21,6 +24,53 @@ func GetConfigValue(key string) string {
 	return viper.GetString(key)
 }
 
+// applyInstanceConfig passes instance-specific settings from the config to the lib package.
+func applyInstanceConfig() {
+	if field := GetConfigValue("story_points_field"); field != "" {
+		lib.SetStoryPointsField(field)
+	}
+	if field := GetConfigValue("epic_link_field"); field != "" {
+		lib.SetEpicLinkField(field)
+	}
+	lib.SetWorkingTime(viper.GetFloat64("hours_per_day"), viper.GetFloat64("days_per_week"))
+
+	if tz := GetConfigValue("timezone"); tz != "" {
+		loc, err := time.LoadLocation(tz)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: invalid timezone %q in config, using local time: %v\n", tz, err)
+		} else {
+			lib.SetTimezone(loc)
+		}
+	}
+	if board := viper.GetInt("board"); board > 0 {
+		lib.SetSprintDateResolver(func() (time.Time, time.Time, error) {
+			return activeSprintDates(board)
+		})
+	}
+}
+
+// activeSprintDates returns the start and end of the active sprint of a board.
+func activeSprintDates(boardID int) (time.Time, time.Time, error) {
+	sprints, err := lib.FetchBoardSprints(GetConfigValue("jira_url"), GetConfigValue("apikey"), boardID, "active")
+	if err != nil {
+		return time.Time{}, time.Time{}, err
+	}
+	for _, s := range sprints {
+		if s.StartDate != nil && s.EndDate != nil {
+			return *s.StartDate, *s.EndDate, nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("board %d has no active sprint", boardID)
+}
+
+// checkWorkingTime rejects a working time setting that is not positive or exceeds max
+func checkWorkingTime(flag string, value, max float64) error {
+	if value <= 0 || value > max {
+		return fmt.Errorf("--%s must be greater than 0 and at most %g, got %g", flag, max, value)
+	}
+	return nil
+}
+
 // DefaultJiraURL is the default Jira instance URL.
 const DefaultJiraURL = "https://issues.redhat.com"
 
@@ -36,6 +86,12 @@ var setCmd = &cobra.Command{
 		token, _ := cmd.Flags().GetString("token")
 		url, _ := cmd.Flags().GetString("url")
 		user, _ := cmd.Flags().GetString("user")
+		storyPointsField, _ := cmd.Flags().GetString("story-points-field")
+		epicLinkField, _ := cmd.Flags().GetString("epic-link-field")
+		hoursPerDay, _ := cmd.Flags().GetFloat64("hours-per-day")
+		daysPerWeek, _ := cmd.Flags().GetInt("days-per-week")
+		timezone, _ := cmd.Flags().GetString("timezone")
+		board, _ := cmd.Flags().GetString("board")
 
 		if user != "" {
 			SetConfigValue("jira_user", user)
@@ -46,6 +102,42 @@ var setCmd = &cobra.Command{
 			SetConfigValue("apikey", token)
 			fmt.Println("Set Jira API token in config")
 		}
+		if storyPointsField != "" {
+			SetConfigValue("story_points_field", storyPointsField)
+			fmt.Println("Set story points field in config")
+		}
+		if epicLinkField != "" {
+			SetConfigValue("epic_link_field", epicLinkField)
+			fmt.Println("Set epic link field in config")
+		}
+		if cmd.Flags().Changed("hours-per-day") {
+			if err := checkWorkingTime("hours-per-day", hoursPerDay, 24); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			SetConfigValue("hours_per_day", strconv.FormatFloat(hoursPerDay, 'f', -1, 64))
+			fmt.Println("Set working hours per day in config")
+		}
+		if cmd.Flags().Changed("days-per-week") {
+			if err := checkWorkingTime("days-per-week", float64(daysPerWeek), 7); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			SetConfigValue("days_per_week", strconv.Itoa(daysPerWeek))
+			fmt.Println("Set working days per week in config")
+		}
+		if timezone != "" {
+			if _, err := time.LoadLocation(timezone); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: invalid timezone %q: %v\n", timezone, err)
+				os.Exit(1)
+			}
+			SetConfigValue("timezone", timezone)
+			fmt.Println("Set timezone in config")
+		}
+		if board != "" {
+			SetConfigValue("board", board)
+			fmt.Println("Set default board in config")
+		}
 		if url == "" {
 			url = DefaultJiraURL
 		}
@@ -75,6 +167,12 @@ func init() {
 	setCmd.PersistentFlags().StringP("user", "s", "", "The Jira user email to set in the configuration.")
 	setCmd.PersistentFlags().StringP("token", "t", "", "The Jira API token to set in the configuration.")
 	setCmd.PersistentFlags().StringP("url", "u", "", "The Jira URL to set in the configuration.")
+	setCmd.PersistentFlags().String("story-points-field", "", "The custom field ID holding story points (e.g., customfield_12310243).")
+	setCmd.PersistentFlags().String("epic-link-field", "", "The custom field ID holding the epic link (e.g., customfield_12311140).")
+	setCmd.PersistentFlags().Float64("hours-per-day", 0, "Working hours per day used for Jira durations, up to 24 (default 8).")
+	setCmd.PersistentFlags().Int("days-per-week", 0, "Working days per week used for Jira durations, up to 7 (default 5).")
+	setCmd.PersistentFlags().String("timezone", "", "IANA timezone for date ranges, matching your Jira profile (e.g., Europe/Prague).")
+	setCmd.PersistentFlags().String("board", "", "Default board ID used to resolve this-sprint in date ranges.")
 
 	configCmd.AddCommand(setCmd)
 	configCmd.AddCommand(viewCmd)
@@ -43,6 +43,22 @@ func TestSetCmdStructure(t *testing.T) {
 	userFlag := setCmd.PersistentFlags().Lookup("user")
 	assert.NotNil(t, userFlag)
 	assert.Equal(t, "s", userFlag.Shorthand)
+
+	assert.NotNil(t, setCmd.PersistentFlags().Lookup("story-points-field"))
+	assert.NotNil(t, setCmd.PersistentFlags().Lookup("epic-link-field"))
+	assert.Equal(t, "float64", setCmd.PersistentFlags().Lookup("hours-per-day").Value.Type())
+	assert.Equal(t, "int", setCmd.PersistentFlags().Lookup("days-per-week").Value.Type())
+	assert.NotNil(t, setCmd.PersistentFlags().Lookup("timezone"))
+	assert.NotNil(t, setCmd.PersistentFlags().Lookup("board"))
+}
+
+func TestCheckWorkingTime(t *testing.T) {
+	assert.NoError(t, checkWorkingTime("hours-per-day", 7.5, 24))
+	assert.NoError(t, checkWorkingTime("days-per-week", 7, 7))
+	assert.Error(t, checkWorkingTime("hours-per-day", 0, 24))
+	assert.Error(t, checkWorkingTime("hours-per-day", -8, 24))
+	assert.Error(t, checkWorkingTime("hours-per-day", 25, 24))
+	assert.Error(t, checkWorkingTime("days-per-week", 8, 7))
 }
 
 func TestViewCmdStructure(t *testing.T) {
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var forecastCmd = &cobra.Command{
+	Use:   "forecast",
+	Short: "Forecast backlog completion with a Monte Carlo simulation of throughput",
+	Long: `Forecast when the unresolved issues matching --backlog will be done, by replaying the
+daily throughput of the issues matching --throughput resolved over the --lookback period.
+Without --throughput, the throughput comes from the projects of the backlog issues.
+
+Each simulation draws a random day of historical throughput for every day ahead until the
+backlog is done. The report gives the dates by which the backlog is done in 50%, 85% and
+95% of the simulations. With --by it also gives how many items are done by that date with
+the same confidence.
+
+Simulations are random unless --seed is given; the seed used is included in the report so
+a forecast can be reproduced.
+
+Examples:
+  jiracrawler forecast --backlog "fixVersion = 4.16 AND resolution = Unresolved" -o table
+  jiracrawler forecast --backlog "sprint in openSprints()" --throughput "project = CNF" --by 2024-06-30 --lookback -60d
+  jiracrawler forecast --backlog "project = CNF AND resolution = Unresolved" --simulations 10000 --seed 42`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		backlog, _ := cmd.Flags().GetString("backlog")
+		throughput, _ := cmd.Flags().GetString("throughput")
+		lookback, _ := cmd.Flags().GetString("lookback")
+		by, _ := cmd.Flags().GetString("by")
+		simulations, _ := cmd.Flags().GetInt("simulations")
+		seed, _ := cmd.Flags().GetUint64("seed")
+		if !cmd.Flags().Changed("seed") {
+			seed = rand.Uint64()
+		}
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		report, err := lib.FetchForecast(jiraURL, apikey, backlog, throughput, lookback, by, simulations, seed)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(forecastCmd)
+
+	addOutputFlag(forecastCmd, "json", "json", "yaml", "table", "markdown")
+	forecastCmd.Flags().String("backlog", "", "JQL selecting the backlog to forecast")
+	forecastCmd.Flags().String("throughput", "", "JQL selecting the issues whose resolutions give the historical throughput; defaults to the projects of the backlog")
+	forecastCmd.Flags().String("lookback", "-90d", "Start of the throughput history; it runs until yesterday")
+	forecastCmd.Flags().String("by", "", "Also forecast how many items are done by this date")
+	forecastCmd.Flags().Int("simulations", 10000, "Number of simulations")
+	forecastCmd.Flags().Uint64("seed", 0, "Random seed for a reproducible forecast")
+	_ = forecastCmd.MarkFlagRequired("backlog")
+}
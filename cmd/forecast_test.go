@@ -0,0 +1,27 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestForecastCmdStructure(t *testing.T) {
+	assert.Equal(t, "forecast", forecastCmd.Use)
+
+	outputFlag := forecastCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "10000", forecastCmd.Flags().Lookup("simulations").DefValue)
+	assert.Equal(t, "-90d", forecastCmd.Flags().Lookup("lookback").DefValue)
+	assert.Equal(t, "0", forecastCmd.Flags().Lookup("seed").DefValue)
+	assert.Equal(t, "", forecastCmd.Flags().Lookup("by").DefValue)
+
+	required := forecastCmd.Flags().Lookup("backlog").Annotations[cobra.BashCompOneRequiredFlag]
+	assert.Equal(t, []string{"true"}, required)
+	assert.Empty(t, forecastCmd.Flags().Lookup("throughput").Annotations[cobra.BashCompOneRequiredFlag])
+	assert.Error(t, forecastCmd.Args(forecastCmd, []string{"extra"}))
+}
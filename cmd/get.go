@@ -3,6 +3,7 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/sebrandon1/jiracrawler/lib"
 	"github.com/spf13/cobra"
@@ -28,11 +29,43 @@ func printOutput(format string, data interface{}) error {
 		return lib.PrintYAML(data)
 	case "table":
 		return lib.PrintTable(data)
+	case "markdown", "md":
+		return lib.PrintMarkdown(data)
+	case "csv":
+		return lib.PrintCSV(data)
+	case "svg":
+		return lib.PrintSVG(data)
 	default:
 		return lib.PrintJSON(data)
 	}
 }
 
+// addIssueListFlags registers the column selection and triage filter flags shared by
+// commands that print lists of issues.
+func addIssueListFlags(cmd *cobra.Command) {
+	cmd.Flags().StringSlice("columns", nil, "Table columns to show: "+strings.Join(lib.IssueColumnNames(), ","))
+	cmd.Flags().String("fix-version", "", "Only include issues with this fix version")
+	cmd.Flags().String("affects-version", "", "Only include issues affecting this version")
+	cmd.Flags().Int("min-watches", 0, "Only include issues with at least this many watchers")
+	cmd.Flags().Int("min-votes", 0, "Only include issues with at least this many votes")
+}
+
+// printIssueResults applies the issue list flags to data and prints it in the given format.
+func printIssueResults(cmd *cobra.Command, format string, data interface{}) error {
+	filter := lib.IssueFilter{}
+	filter.FixVersion, _ = cmd.Flags().GetString("fix-version")
+	filter.AffectsVersion, _ = cmd.Flags().GetString("affects-version")
+	filter.MinWatches, _ = cmd.Flags().GetInt("min-watches")
+	filter.MinVotes, _ = cmd.Flags().GetInt("min-votes")
+	lib.ApplyIssueFilter(data, filter)
+
+	columns, _ := cmd.Flags().GetStringSlice("columns")
+	if format == "table" && len(columns) > 0 {
+		return lib.PrintIssueTable(data, columns)
+	}
+	return printOutput(format, data)
+}
+
 var getCmd = &cobra.Command{
 	Use:   "get",
 	Short: "Get Jira data",
@@ -41,54 +74,165 @@ var getCmd = &cobra.Command{
 var assignedIssuesCmd = &cobra.Command{
 	Use:   "assignedissues [users...]",
 	Short: "Get assigned issues for users",
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Get the issues assigned to one or more users in a project.
+
+Use --team to query the members of a team defined in the config. Team results are
+grouped per member with team totals, and the team's default project is used unless
+--projectID is given.
+
+Examples:
+  jiracrawler get assignedissues user1@example.com user2@example.com -p CNF
+  jiracrawler get assignedissues --team cnf -o table`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if team, _ := cmd.Flags().GetString("team"); team != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	ValidArgsFunction: completeAssignees,
 	Run: func(cmd *cobra.Command, args []string) {
 		output, _ := cmd.Flags().GetString("output")
 		projectID, _ := cmd.Flags().GetString("projectID")
+		teamName, _ := cmd.Flags().GetString("team")
 		if projectID == "" {
 			projectID = "CNF"
 		}
 		apikey, jiraURL, jiraUser := validateConfig()
-		users := args
-		results, err := lib.FetchAssignedIssuesWithProject(jiraURL, jiraUser, apikey, projectID, users)
+		applyInstanceConfig()
+
+		var results interface{}
+		var err error
+		if teamName != "" {
+			team := lookupTeam(teamName)
+			if team.Project != "" && !cmd.Flags().Changed("projectID") {
+				projectID = team.Project
+			}
+			team.Members = resolveUserArgs(cmd, jiraURL, apikey, team.Members)
+			results, err = lib.FetchTeamAssignedIssues(jiraURL, jiraUser, apikey, projectID, team)
+		} else {
+			users := resolveUserArgs(cmd, jiraURL, apikey, args)
+			results, err = lib.FetchAssignedIssuesWithProject(jiraURL, jiraUser, apikey, projectID, users)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
-		if err := printOutput(output, results); err != nil {
+		if err := printIssueResults(cmd, output, results); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 	},
 }
 
+// userUpdatesArgs splits the arguments of userupdates into users and dates. Dates come
+// from --from/--to when set, otherwise from the last two arguments.
+func userUpdatesArgs(cmd *cobra.Command, args []string) (users []string, startDate, endDate string, err error) {
+	from, _ := cmd.Flags().GetString("from")
+	to, _ := cmd.Flags().GetString("to")
+	flagUsers, _ := cmd.Flags().GetStringSlice("user")
+	team, _ := cmd.Flags().GetString("team")
+
+	switch {
+	case from != "" || to != "":
+		if from == "" || to == "" {
+			return nil, "", "", fmt.Errorf("--from and --to must be used together")
+		}
+		users, startDate, endDate = args, from, to
+	case len(args) < 2:
+		return nil, "", "", fmt.Errorf("a start date and an end date are required")
+	default:
+		users, startDate, endDate = args[:len(args)-2], args[len(args)-2], args[len(args)-1]
+	}
+	users = append(append([]string{}, users...), flagUsers...)
+
+	if team != "" && len(users) > 0 {
+		return nil, "", "", fmt.Errorf("--team cannot be combined with user arguments")
+	}
+	if team == "" && len(users) == 0 {
+		return nil, "", "", fmt.Errorf("at least one user is required")
+	}
+	return users, startDate, endDate, nil
+}
+
 var userUpdatesCmd = &cobra.Command{
-	Use:   "userupdates [user] [start-date] [end-date]",
-	Short: "Get issues assigned to a user that were updated within a date range",
-	Long: `Get all issues assigned to a specific user that were updated within the specified date range.
+	Use:   "userupdates [users...] [start-date] [end-date]",
+	Short: "Get issues assigned to users that were updated within a date range",
+	Long: `Get all issues assigned to one or more users that were updated within the specified date range.
+
+Dates are given either as the last two arguments or with --from and --to. Besides
+YYYY-MM-DD they accept RFC3339 timestamps, relative offsets (-7d), keywords (today,
+yesterday, last-week, this-sprint), ISO weeks (2024-W03) and quarters (2024-Q1). The end
+date is inclusive and dates use the timezone from the config. Users can be given as arguments, with --user (repeatable), or with
+--team. Several users are fetched concurrently and their results are grouped with
+combined totals. A single user argument gives that user's result alone; --user and
+--team always give the grouped result, even for one user.
 
-Dates should be in YYYY-MM-DD format.
+With --activity, the command reports what each user did instead of what is assigned
+to them: issues they created, commented on, transitioned, reassigned or otherwise
+changed within the range, as a per-user timeline. This replays the comments and
+history of every candidate issue, so it makes two extra requests per issue.
 
-Example:
-  jiracrawler get userupdates user@example.com 2024-01-01 2024-01-31`,
-	Args: cobra.ExactArgs(3),
+Examples:
+  jiracrawler get userupdates user@example.com 2024-01-01 2024-01-31
+  jiracrawler get userupdates --user alice@example.com --user bob@example.com --from 2024-01-01 --to 2024-01-31
+  jiracrawler get userupdates --team cnf 2024-01-01 2024-01-31 -o table
+  jiracrawler get userupdates user@example.com last-week last-week
+  jiracrawler get userupdates --team cnf --activity yesterday today -o markdown`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		_, _, _, err := userUpdatesArgs(cmd, args)
+		return err
+	},
 	Run: func(cmd *cobra.Command, args []string) {
 		output, _ := cmd.Flags().GetString("output")
+		teamName, _ := cmd.Flags().GetString("team")
+		activity, _ := cmd.Flags().GetBool("activity")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		users, startDate, endDate, err := userUpdatesArgs(cmd, args)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
 
 		apikey, jiraURL, jiraUser := validateConfig()
-		_ = jiraUser
+		applyInstanceConfig()
 
-		assignee := args[0]
-		startDate := args[1]
-		endDate := args[2]
+		if activity {
+			if teamName != "" {
+				users = lookupTeam(teamName).Members
+			}
+			users = resolveUserArgs(cmd, jiraURL, apikey, users)
+			report, err := lib.FetchUserActivity(jiraURL, jiraUser, apikey, users, startDate, endDate, verbose)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			if err := printOutput(output, report); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+				os.Exit(1)
+			}
+			return
+		}
 
-		result, err := lib.FetchUserIssuesInDateRange(jiraURL, jiraUser, apikey, assignee, startDate, endDate)
+		var result interface{}
+		switch {
+		case teamName != "":
+			team := lookupTeam(teamName)
+			team.Members = resolveUserArgs(cmd, jiraURL, apikey, team.Members)
+			result, err = lib.FetchTeamUserUpdates(jiraURL, jiraUser, apikey, team, startDate, endDate)
+		case len(users) == 1 && !cmd.Flags().Changed("user"):
+			assignee := resolveUserArgs(cmd, jiraURL, apikey, users)[0]
+			result, err = lib.FetchUserIssuesInDateRange(jiraURL, jiraUser, apikey, assignee, startDate, endDate)
+		default:
+			users = resolveUserArgs(cmd, jiraURL, apikey, users)
+			result, err = lib.FetchMultiUserUpdates(jiraURL, jiraUser, apikey, users, startDate, endDate)
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
 
-		if err := printOutput(output, result); err != nil {
+		if err := printIssueResults(cmd, output, result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -99,10 +243,22 @@ func init() {
 	getCmd.AddCommand(assignedIssuesCmd)
 	getCmd.AddCommand(userUpdatesCmd)
 
-	assignedIssuesCmd.Flags().StringP("output", "o", "json", "Output format: json|yaml|table")
+	addOutputFlag(assignedIssuesCmd, "json", "json", "yaml", "table")
 	assignedIssuesCmd.PersistentFlags().StringP("projectID", "p", "CNF", "Jira project key (e.g., CNF)")
 
-	userUpdatesCmd.Flags().StringP("output", "o", "json", "Output format: json|yaml|table")
+	addOutputFlag(userUpdatesCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	userUpdatesCmd.Flags().StringSliceP("user", "u", nil, "User to query (repeatable)")
+	userUpdatesCmd.Flags().String("from", "", "Start date (YYYY-MM-DD, -7d, last-week, 2024-W03, ...)")
+	userUpdatesCmd.Flags().String("to", "", "End date, inclusive (YYYY-MM-DD, today, ...)")
+	userUpdatesCmd.Flags().Bool("activity", false, "Report a timeline of the actions each user took instead of assigned issues")
+	userUpdatesCmd.Flags().BoolP("verbose", "v", false, "Print progress while fetching comments and history")
+
+	addIssueListFlags(assignedIssuesCmd)
+	addIssueListFlags(userUpdatesCmd)
+	addResolveFlag(assignedIssuesCmd)
+	addResolveFlag(userUpdatesCmd)
+	addTeamFlag(assignedIssuesCmd)
+	addTeamFlag(userUpdatesCmd)
 
 	// Ensure getCmd and assignedIssuesCmd are initialized for root.go
 }
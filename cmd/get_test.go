@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -29,14 +30,52 @@ func TestAssignedIssuesCmdStructure(t *testing.T) {
 }
 
 func TestUserUpdatesCmdStructure(t *testing.T) {
-	assert.Equal(t, "userupdates [user] [start-date] [end-date]", userUpdatesCmd.Use)
-	assert.Equal(t, "Get issues assigned to a user that were updated within a date range", userUpdatesCmd.Short)
+	assert.Equal(t, "userupdates [users...] [start-date] [end-date]", userUpdatesCmd.Use)
+	assert.Equal(t, "Get issues assigned to users that were updated within a date range", userUpdatesCmd.Short)
 
 	// Verify flags
 	outputFlag := userUpdatesCmd.Flags().Lookup("output")
 	assert.NotNil(t, outputFlag)
 	assert.Equal(t, "o", outputFlag.Shorthand)
 	assert.Equal(t, "json", outputFlag.DefValue)
+
+	userFlag := userUpdatesCmd.Flags().Lookup("user")
+	assert.NotNil(t, userFlag)
+	assert.Equal(t, "u", userFlag.Shorthand)
+	assert.NotNil(t, userUpdatesCmd.Flags().Lookup("from"))
+	assert.NotNil(t, userUpdatesCmd.Flags().Lookup("to"))
+
+	activityFlag := userUpdatesCmd.Flags().Lookup("activity")
+	assert.NotNil(t, activityFlag)
+	assert.Equal(t, "false", activityFlag.DefValue)
+	assert.NotNil(t, userUpdatesCmd.Flags().Lookup("verbose"))
+}
+
+func TestUserUpdatesArgs(t *testing.T) {
+	users, start, end, err := userUpdatesArgs(userUpdatesCmd, []string{"alice", "bob", "2024-01-01", "2024-01-31"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, users)
+	assert.Equal(t, "2024-01-01", start)
+	assert.Equal(t, "2024-01-31", end)
+
+	assert.NoError(t, userUpdatesCmd.Flags().Set("from", "2024-02-01"))
+	assert.NoError(t, userUpdatesCmd.Flags().Set("to", "2024-02-29"))
+	assert.NoError(t, userUpdatesCmd.Flags().Set("user", "carol"))
+	defer func() {
+		_ = userUpdatesCmd.Flags().Set("from", "")
+		_ = userUpdatesCmd.Flags().Set("to", "")
+		_ = userUpdatesCmd.Flags().Lookup("user").Value.(pflag.SliceValue).Replace(nil)
+	}()
+
+	users, start, end, err = userUpdatesArgs(userUpdatesCmd, []string{"alice"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"alice", "carol"}, users)
+	assert.Equal(t, "2024-02-01", start)
+	assert.Equal(t, "2024-02-29", end)
+
+	assert.NoError(t, userUpdatesCmd.Flags().Set("to", ""))
+	_, _, _, err = userUpdatesArgs(userUpdatesCmd, []string{"alice"})
+	assert.Error(t, err)
 }
 
 func TestPrintOutput_JSON(t *testing.T) {
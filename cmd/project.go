@@ -0,0 +1,87 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var projectCmd = &cobra.Command{
+	Use:   "project",
+	Short: "Show project metadata",
+}
+
+var projectListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the projects visible to the configured user",
+	Long: `List the projects visible to the configured user.
+
+The list is cached locally and used for shell completion of --projectID.
+
+Example:
+  jiracrawler project list -o table`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		result, err := lib.FetchProjects(jiraURL, apikey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := lib.SaveCache(jiraURL, lib.ProjectsCacheName, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache projects: %v\n", err)
+		}
+
+		if err := printOutput(output, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var projectInfoCmd = &cobra.Command{
+	Use:   "info [project-key]",
+	Short: "Show the components, versions, issue types and statuses of a project",
+	Long: `Show the components (with their leads), versions, issue types and the workflow
+statuses available to each issue type of a project.
+
+The result is cached locally and used for shell completion of status values.
+
+Example:
+  jiracrawler project info CNF -o table`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectKeyArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		info, err := lib.FetchProjectInfo(jiraURL, apikey, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := lib.SaveCache(jiraURL, lib.ProjectCacheName(args[0]), info); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to cache project info: %v\n", err)
+		}
+
+		if err := printOutput(output, info); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(projectCmd)
+	projectCmd.AddCommand(projectListCmd)
+	projectCmd.AddCommand(projectInfoCmd)
+
+	addOutputFlag(projectListCmd, "json", "json", "yaml", "table")
+	addOutputFlag(projectInfoCmd, "json", "json", "yaml", "table")
+}
@@ -0,0 +1,30 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectCmdStructure(t *testing.T) {
+	assert.Equal(t, "project", projectCmd.Use)
+
+	names := make([]string, 0)
+	for _, cmd := range projectCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "list")
+	assert.Contains(t, names, "info")
+
+	assert.Equal(t, "info [project-key]", projectInfoCmd.Use)
+	assert.NotNil(t, projectInfoCmd.Flags().Lookup("output"))
+	assert.NotNil(t, projectListCmd.Flags().Lookup("output"))
+}
+
+func TestProjectCmdRegistered(t *testing.T) {
+	names := make([]string, 0)
+	for _, cmd := range rootCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "project")
+}
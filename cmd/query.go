@@ -32,6 +32,8 @@ Examples:
 			os.Exit(1)
 		}
 
+		applyInstanceConfig()
+
 		jql := args[0]
 		result, err := lib.FetchIssuesWithJQL(jiraURL, apikey, jql, maxResults)
 		if err != nil {
@@ -39,7 +41,7 @@ Examples:
 			os.Exit(1)
 		}
 
-		if err := printOutput(output, result); err != nil {
+		if err := printIssueResults(cmd, output, result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
 			os.Exit(1)
 		}
@@ -48,6 +50,7 @@ Examples:
 
 func init() {
 	getCmd.AddCommand(queryCmd)
-	queryCmd.Flags().StringP("output", "o", "json", "Output format: json|yaml|table")
+	addOutputFlag(queryCmd, "json", "json", "yaml", "table")
 	queryCmd.Flags().IntP("max-results", "m", 50, "Maximum number of results to return")
+	addIssueListFlags(queryCmd)
 }
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// statusCategoriesCacheTTL is how long the status categories read from Jira are cached
+const statusCategoriesCacheTTL = 24 * time.Hour
+
+var reportCmd = &cobra.Command{
+	Use:   "report",
+	Short: "Generate reports from Jira data",
+}
+
+var sprintReportCmd = &cobra.Command{
+	Use:   "sprint [sprint-id]",
+	Short: "Report committed, added, removed, completed and carried-over issues for a sprint",
+	Long: `Classify the issues of a sprint by replaying each issue's changelog against the
+sprint start and end dates.
+
+Issues in the sprint at its start are "committed", issues that joined afterwards are
+"added". At the end of the sprint each issue is "completed", "carried-over", or
+"removed" if it left the sprint. Totals are reported in issues and story points.
+
+Issues removed from a sprint no longer match "sprint = <id>", so use --scope-jql to
+include the candidate issues that should also be checked.
+
+Examples:
+  jiracrawler report sprint 12345 -o table
+  jiracrawler report sprint 12345 --scope-jql "project = CNF AND updated >= -30d" -o markdown`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		scopeJQL, _ := cmd.Flags().GetString("scope-jql")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		sprintID, err := strconv.Atoi(args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: invalid sprint ID %q\n", args[0])
+			os.Exit(1)
+		}
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		report, err := lib.FetchSprintReport(jiraURL, apikey, sprintID, scopeJQL, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// loadStatusCategories returns the status categories of the instance, read from Jira (and
+// cached) then overridden by the status_categories section of the config.
+func loadStatusCategories(jiraURL, apikey string) lib.StatusCategories {
+	var categories lib.StatusCategories
+	if !lib.LoadCache(jiraURL, lib.StatusCategoriesCacheName, statusCategoriesCacheTTL, &categories) {
+		fetched, err := lib.FetchStatusCategories(jiraURL, apikey)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: failed to read status categories from Jira: %v\n", err)
+		} else {
+			categories = fetched
+			if err := lib.SaveCache(jiraURL, lib.StatusCategoriesCacheName, categories); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: failed to cache status categories: %v\n", err)
+			}
+		}
+	}
+
+	var configured map[string][]string
+	if err := viper.UnmarshalKey("status_categories", &configured); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: reading status_categories from config: %v\n", err)
+		os.Exit(1)
+	}
+	overrides, err := lib.NewStatusCategories(configured)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return categories.With(overrides)
+}
+
+func init() {
+	rootCmd.AddCommand(reportCmd)
+	reportCmd.AddCommand(sprintReportCmd)
+
+	reportCmd.PersistentFlags().BoolP("verbose", "v", false, "Print progress while fetching issue history")
+
+	addOutputFlag(sprintReportCmd, "json", "json", "yaml", "table", "markdown")
+	sprintReportCmd.Flags().String("scope-jql", "", "Additional JQL selecting issues that may have been removed from the sprint")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var agingReportCmd = &cobra.Command{
+	Use:   "aging [jql]",
+	Short: "Report the age of unresolved in-progress issues",
+	Long: `List the unresolved issues matching a JQL query that are in a status of the "in progress"
+category, oldest first, with the days since they entered their current status and since
+work on them started.
+
+Issues are flagged when they have been in their current status longer than --status-days,
+in progress longer than --work-days, or in progress longer than the 85th percentile cycle
+time of the resolved issues matched by --history. A threshold of 0 disables it.
+
+Status categories are read from Jira and can be overridden in the status_categories
+section of the config.
+
+Examples:
+  jiracrawler report aging "project = CNF AND resolution = Unresolved" -o table
+  jiracrawler report aging "sprint in openSprints()" --status-days 3 --work-days 0 -o markdown
+  jiracrawler report aging "project = CNF AND resolution = Unresolved" --history "project = CNF AND resolved >= -90d"`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		history, _ := cmd.Flags().GetString("history")
+		statusDays, _ := cmd.Flags().GetFloat64("status-days")
+		workDays, _ := cmd.Flags().GetFloat64("work-days")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		thresholds := lib.AgingThresholds{StatusDays: statusDays, WorkDays: workDays}
+		report, err := lib.FetchAgingReport(jiraURL, apikey, args[0], history, categories, thresholds, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(agingReportCmd)
+
+	addOutputFlag(agingReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	agingReportCmd.Flags().Float64("status-days", 5, "Flag issues in their current status for more days (0 disables)")
+	agingReportCmd.Flags().Float64("work-days", 10, "Flag issues in progress for more days (0 disables)")
+	agingReportCmd.Flags().String("history", "", "JQL selecting resolved issues to flag issues past their 85th percentile cycle time")
+}
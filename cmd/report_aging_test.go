@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAgingReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "aging [jql]", agingReportCmd.Use)
+
+	outputFlag := agingReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "5", agingReportCmd.Flags().Lookup("status-days").DefValue)
+	assert.Equal(t, "10", agingReportCmd.Flags().Lookup("work-days").DefValue)
+	assert.Equal(t, "", agingReportCmd.Flags().Lookup("history").DefValue)
+	assert.Error(t, agingReportCmd.Args(agingReportCmd, []string{}))
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var burndownReportCmd = &cobra.Command{
+	Use:   "burndown",
+	Short: "Report the daily burndown of a sprint or version",
+	Long: `Reconstruct the work remaining in a sprint or version at the end of each day by replaying
+the status, Sprint (or Fix Version) and story point changes of its issues, next to the
+ideal burndown from the work committed at the start down to zero on the last day.
+
+Each day also gives the total scope and the completed work, for a burnup chart. Work is
+completed when its status is in the done category. Use --unit issues to count issues
+instead of story points.
+
+A sprint runs from its start to its planned end date. A version runs from its start date
+to its release date, or to today when it has none; use --from and --to to change them.
+Issues that left the sprint or version no longer match its JQL, so use --scope-jql to
+include the candidate issues that should also be checked.
+
+Examples:
+  jiracrawler report burndown --sprint 12345 -o table
+  jiracrawler report burndown --sprint 12345 --unit issues -o svg > burndown.svg
+  jiracrawler report burndown --version 4.16 --project CNF --from 2024-01-08 -o csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		sprintID, _ := cmd.Flags().GetInt("sprint")
+		version, _ := cmd.Flags().GetString("version")
+		project, _ := cmd.Flags().GetString("project")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		unit, _ := cmd.Flags().GetString("unit")
+		scopeJQL, _ := cmd.Flags().GetString("scope-jql")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		var report *lib.BurndownReport
+		var err error
+		if version != "" {
+			report, err = lib.FetchVersionBurndown(jiraURL, apikey, project, version, from, to, scopeJQL, unit, categories, verbose)
+		} else {
+			report, err = lib.FetchSprintBurndown(jiraURL, apikey, sprintID, scopeJQL, unit, categories, verbose)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(burndownReportCmd)
+
+	addOutputFlag(burndownReportCmd, "json", "json", "yaml", "table", "csv", "svg")
+	burndownReportCmd.Flags().Int("sprint", 0, "Sprint ID")
+	burndownReportCmd.Flags().String("version", "", "Fix version name")
+	burndownReportCmd.Flags().String("project", "", "Project key of the version")
+	burndownReportCmd.Flags().String("from", "", "First day of a version burndown (default: the version start date)")
+	burndownReportCmd.Flags().String("to", "", "Last day of a version burndown (default: the version release date)")
+	burndownReportCmd.Flags().String("unit", lib.BurndownPoints, "Unit of work: points|issues")
+	burndownReportCmd.Flags().String("scope-jql", "", "Additional JQL selecting issues that may have left the sprint or version")
+	burndownReportCmd.MarkFlagsOneRequired("sprint", "version")
+	burndownReportCmd.MarkFlagsMutuallyExclusive("sprint", "version")
+	burndownReportCmd.MarkFlagsRequiredTogether("version", "project")
+	burndownReportCmd.MarkFlagsMutuallyExclusive("sprint", "from")
+	burndownReportCmd.MarkFlagsMutuallyExclusive("sprint", "to")
+}
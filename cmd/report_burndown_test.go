@@ -0,0 +1,44 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurndownReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "burndown", burndownReportCmd.Use)
+
+	outputFlag := burndownReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "points", burndownReportCmd.Flags().Lookup("unit").DefValue)
+	for _, name := range []string{"sprint", "version", "project", "from", "to", "scope-jql"} {
+		assert.NotNil(t, burndownReportCmd.Flags().Lookup(name), name)
+	}
+	assert.Error(t, burndownReportCmd.Args(burndownReportCmd, []string{"12345"}))
+}
+
+func TestBurndownReportCmdFlagGroups(t *testing.T) {
+	flags := burndownReportCmd.Flags()
+	defer func() {
+		for _, name := range []string{"sprint", "version", "project"} {
+			flag := flags.Lookup(name)
+			_ = flag.Value.Set(flag.DefValue)
+			flag.Changed = false
+		}
+	}()
+
+	assert.Error(t, burndownReportCmd.ValidateFlagGroups())
+
+	assert.NoError(t, flags.Set("version", "4.16"))
+	assert.Error(t, burndownReportCmd.ValidateFlagGroups())
+
+	assert.NoError(t, flags.Set("project", "CNF"))
+	assert.NoError(t, burndownReportCmd.ValidateFlagGroups())
+
+	assert.NoError(t, flags.Set("sprint", "12345"))
+	assert.Error(t, burndownReportCmd.ValidateFlagGroups())
+}
@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var cfdReportCmd = &cobra.Command{
+	Use:   "cfd [jql]",
+	Short: "Report daily issue counts per status for a cumulative flow diagram",
+	Long: `Count, at the end of each day between --from and --to, how many of the issues matching a
+JQL query were in each status. Counts are reconstructed from the status history, and
+issues are counted from the day they were created.
+
+Statuses are ordered from to do through done using the status categories read from Jira,
+which can be overridden in the status_categories section of the config.
+
+Use -o csv or -o json for data suitable for plotting, or -o svg for a stacked area chart.
+
+Examples:
+  jiracrawler report cfd "project = CNF AND fixVersion = 4.16" -o table
+  jiracrawler report cfd "project = CNF" --from 2024-01-01 --to 2024-03-31 -o csv
+  jiracrawler report cfd "sprint in openSprints()" --from this-sprint -o svg > cfd.svg`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		report, err := lib.FetchCFDReport(jiraURL, apikey, args[0], from, to, categories, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(cfdReportCmd)
+
+	addOutputFlag(cfdReportCmd, "json", "json", "yaml", "table", "markdown", "csv", "svg")
+	cfdReportCmd.Flags().String("from", "-30d", "First day of the diagram")
+	cfdReportCmd.Flags().String("to", "today", "Last day of the diagram")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCFDReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "cfd [jql]", cfdReportCmd.Use)
+
+	outputFlag := cfdReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "-30d", cfdReportCmd.Flags().Lookup("from").DefValue)
+	assert.Equal(t, "today", cfdReportCmd.Flags().Lookup("to").DefValue)
+	assert.Error(t, cfdReportCmd.Args(cfdReportCmd, []string{}))
+}
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var churnReportCmd = &cobra.Command{
+	Use:   "churn [jql]",
+	Short: "Report issues that moved backwards, were reopened or changed hands often",
+	Long: `Replay the changelog of the issues matching a JQL query to find rework and unsettled
+ownership: status changes that move an issue backwards in the workflow, reopens of done
+issues, and repeated assignee or priority changes.
+
+A status change is backward when it moves to an earlier status of --workflow, an ordered
+list of statuses, or otherwise to an earlier status category (Done to In Progress). A
+reopen is a move out of the done category. Issues are flagged for backward moves, reopens,
+and at least --min-changes reassignments or priority changes.
+
+Issues with churn are listed worst first, by the sum of their backward moves (reopens
+included), reassignments and priority changes, followed by the backward transitions most
+frequent first. Status categories are read from Jira and can be overridden in the
+status_categories section of the config.
+
+Examples:
+  jiracrawler report churn "project = CNF AND updated >= -90d" -o table
+  jiracrawler report churn "project = CNF AND resolved >= -30d" --workflow New,Dev,QE,Done --top 10 -o markdown`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		workflow, _ := cmd.Flags().GetStringSlice("workflow")
+		minChanges, _ := cmd.Flags().GetInt("min-changes")
+		top, _ := cmd.Flags().GetInt("top")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		report, err := lib.FetchChurnReport(jiraURL, apikey, args[0], workflow, categories, minChanges, top, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(churnReportCmd)
+
+	addOutputFlag(churnReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	churnReportCmd.Flags().StringSlice("workflow", nil, "Statuses in workflow order, to find backward moves within a status category")
+	churnReportCmd.Flags().Int("min-changes", lib.DefaultChurnMinChanges, "Flag issues with at least this many reassignments or priority changes (0 disables)")
+	churnReportCmd.Flags().Int("top", 0, "List only the worst issues (0 lists all)")
+}
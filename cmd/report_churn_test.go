@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChurnReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "churn [jql]", churnReportCmd.Use)
+
+	outputFlag := churnReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "[]", churnReportCmd.Flags().Lookup("workflow").DefValue)
+	assert.Equal(t, "3", churnReportCmd.Flags().Lookup("min-changes").DefValue)
+	assert.Equal(t, "0", churnReportCmd.Flags().Lookup("top").DefValue)
+	assert.Error(t, churnReportCmd.Args(churnReportCmd, []string{}))
+}
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var flowReportCmd = &cobra.Command{
+	Use:   "flow [jql]",
+	Short: "Report lead time and cycle time of resolved issues",
+	Long: `Compute the lead time and cycle time of the resolved issues matching a JQL query.
+
+Lead time runs from creation to resolution. Cycle time runs from the first entry into a
+status of the "in progress" category to the entry into the done status the issue ended
+in; issues that never entered an in-progress status have no cycle time.
+
+Status categories are read from Jira and can be overridden in the status_categories
+section of the config. The report includes the mean, median, 85th and 95th percentile
+and maximum in days, histograms, and a breakdown per issue type.
+
+Examples:
+  jiracrawler report flow "project = CNF AND resolved >= -90d" -o table
+  jiracrawler report flow "project = CNF AND resolved >= -90d" --bin-days 2 -o csv`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		binDays, _ := cmd.Flags().GetFloat64("bin-days")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		report, err := lib.FetchFlowReport(jiraURL, apikey, args[0], categories, binDays, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(flowReportCmd)
+
+	addOutputFlag(flowReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	flowReportCmd.Flags().Float64("bin-days", 0, "Histogram bin width in days, at least 0.5 (0 chooses it automatically)")
+}
@@ -0,0 +1,39 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlowReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "flow [jql]", flowReportCmd.Use)
+
+	outputFlag := flowReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "0", flowReportCmd.Flags().Lookup("bin-days").DefValue)
+	assert.Error(t, flowReportCmd.Args(flowReportCmd, []string{}))
+}
+
+func TestLoadStatusCategories(t *testing.T) {
+	lib.SetCacheDir(t.TempDir())
+	defer lib.SetCacheDir("")
+	defer viper.Reset()
+
+	jiraURL := "https://issues.example.com"
+	assert.NoError(t, lib.SaveCache(jiraURL, lib.StatusCategoriesCacheName, lib.StatusCategories{
+		"in progress": lib.CategoryInProgress,
+		"qe review":   lib.CategoryToDo,
+	}))
+	viper.Set("status_categories", map[string][]string{"in progress": {"QE Review"}})
+
+	categories := loadStatusCategories(jiraURL, "token")
+	assert.Equal(t, lib.CategoryInProgress, categories.Category("In Progress"))
+	assert.Equal(t, lib.CategoryInProgress, categories.Category("QE Review"))
+	assert.Equal(t, lib.CategoryDone, categories.Category("Closed"))
+}
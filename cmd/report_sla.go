@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// slaExitCode is the exit status when issues breach (or, with --fail-on-risk, are at risk
+// of breaching) an SLA, distinct from the status of other errors
+const slaExitCode = 2
+
+var slaReportCmd = &cobra.Command{
+	Use:   "sla [jql]",
+	Short: "Report issues breaching or at risk of breaching their SLA",
+	Long: `Check the issues matching a JQL query against the SLA rules in the sla_rules section of
+the config, and list those that breached a target or are at risk of breaching one.
+
+Each issue is checked against the first rule matching its priority and issue type. The
+first response is the first comment by someone other than the reporter; resolution is
+the resolution date. An open target is at risk once --at-risk of it has elapsed.
+
+The command exits with status 2 when an issue breached a target, or with --fail-on-risk
+when an issue is at risk, so it can gate CI pipelines.
+
+Examples:
+  jiracrawler report sla "project = CNF AND type = Bug AND created >= -30d" -o table
+  jiracrawler report sla "project = CNF AND type = Bug AND resolution = Unresolved" --fail-on-risk -o markdown`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		atRisk, _ := cmd.Flags().GetFloat64("at-risk")
+		failOnRisk, _ := cmd.Flags().GetBool("fail-on-risk")
+		workdayStartValue, _ := cmd.Flags().GetString("workday-start")
+
+		workdayStart, err := parseClock(workdayStartValue)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		if atRisk <= 0 || atRisk > 1 {
+			fmt.Fprintln(os.Stderr, "Error: --at-risk must be greater than 0 and at most 1")
+			os.Exit(1)
+		}
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		rules, err := loadSLARules()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		report, err := lib.FetchSLAReport(jiraURL, apikey, args[0], rules, atRisk, workdayStart, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if report.Breached > 0 || (failOnRisk && report.AtRisk > 0) {
+			fmt.Fprintf(os.Stderr, "SLA check failed: %d breached, %d at risk\n", report.Breached, report.AtRisk)
+			os.Exit(slaExitCode)
+		}
+	},
+}
+
+// loadSLARules reads the sla_rules section of the config. Targets are durations such as
+// "4h" or "5d": elapsed calendar time, or working time for rules with business_hours.
+func loadSLARules() ([]lib.SLARule, error) {
+	var raw []struct {
+		Name          string `mapstructure:"name"`
+		Priority      string `mapstructure:"priority"`
+		IssueType     string `mapstructure:"issue_type"`
+		FirstResponse string `mapstructure:"first_response"`
+		Resolution    string `mapstructure:"resolution"`
+		BusinessHours bool   `mapstructure:"business_hours"`
+	}
+	if err := viper.UnmarshalKey("sla_rules", &raw); err != nil {
+		return nil, fmt.Errorf("reading sla_rules from config: %w", err)
+	}
+
+	rules := make([]lib.SLARule, 0, len(raw))
+	for i, r := range raw {
+		parse := lib.ParseCalendarDuration
+		if r.BusinessHours {
+			parse = lib.ParseJiraDuration
+		}
+		rule := lib.SLARule{Name: r.Name, Priority: r.Priority, IssueType: r.IssueType, BusinessHours: r.BusinessHours}
+		if rule.Name == "" {
+			rule.Name = fmt.Sprintf("rule %d", i+1)
+		}
+
+		var err error
+		if rule.FirstResponse, err = parse(r.FirstResponse); err != nil {
+			return nil, fmt.Errorf("SLA rule %q: first_response: %w", rule.Name, err)
+		}
+		if rule.Resolution, err = parse(r.Resolution); err != nil {
+			return nil, fmt.Errorf("SLA rule %q: resolution: %w", rule.Name, err)
+		}
+		if rule.FirstResponse == 0 && rule.Resolution == 0 {
+			return nil, fmt.Errorf("SLA rule %q has no first_response or resolution target", rule.Name)
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+func init() {
+	reportCmd.AddCommand(slaReportCmd)
+
+	addOutputFlag(slaReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	slaReportCmd.Flags().Float64("at-risk", lib.DefaultSLAAtRisk, "Share of a target after which an open target is at risk")
+	slaReportCmd.Flags().Bool("fail-on-risk", false, "Also exit with status 2 when an issue is at risk")
+	slaReportCmd.Flags().String("workday-start", "09:00", "Time of day business hours start, for rules with business_hours")
+}
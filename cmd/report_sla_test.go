@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSLAReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "sla [jql]", slaReportCmd.Use)
+
+	outputFlag := slaReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "0.75", slaReportCmd.Flags().Lookup("at-risk").DefValue)
+	assert.Equal(t, "false", slaReportCmd.Flags().Lookup("fail-on-risk").DefValue)
+	assert.Equal(t, "09:00", slaReportCmd.Flags().Lookup("workday-start").DefValue)
+	assert.Error(t, slaReportCmd.Args(slaReportCmd, []string{}))
+}
+
+func TestLoadSLARules(t *testing.T) {
+	defer viper.Reset()
+
+	viper.Set("sla_rules", []map[string]interface{}{
+		{"name": "critical-bugs", "priority": "Critical", "issue_type": "Bug", "first_response": "4h", "resolution": "5d"},
+		{"priority": "Major", "resolution": "2d", "business_hours": true},
+	})
+	rules, err := loadSLARules()
+	assert.NoError(t, err)
+	assert.Len(t, rules, 2)
+	assert.Equal(t, "critical-bugs", rules[0].Name)
+	assert.Equal(t, "Bug", rules[0].IssueType)
+	assert.Equal(t, 4*time.Hour, rules[0].FirstResponse)
+	assert.Equal(t, 5*24*time.Hour, rules[0].Resolution)
+	// Business hours use working days
+	assert.Equal(t, "rule 2", rules[1].Name)
+	assert.Equal(t, 16*time.Hour, rules[1].Resolution)
+
+	viper.Set("sla_rules", []map[string]interface{}{{"name": "empty", "priority": "Minor"}})
+	_, err = loadSLARules()
+	assert.Error(t, err)
+
+	viper.Set("sla_rules", []map[string]interface{}{{"name": "bad", "resolution": "5x"}})
+	_, err = loadSLARules()
+	assert.Error(t, err)
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var staleReportCmd = &cobra.Command{
+	Use:   "stale [jql]",
+	Short: "Report open issues without human activity for a number of days",
+	Long: `Find the open issues matching a JQL query with no comment, change or transition by a
+human for more than --days days, longest idle first, grouped by assignee and by component.
+
+The last activity is read from the comments and changelog of each issue rather than its
+updated date, which automation bumps. Authors listed in the bots section of the config or
+given with --bot are ignored; they are matched by display name, user name or account ID.
+
+Issues that are resolved or in a status of the "done" category are not open. Status
+categories are read from Jira and can be overridden in the status_categories section of
+the config.
+
+Examples:
+  jiracrawler report stale "project = CNF" --days 30 -o table
+  jiracrawler report stale "project = CNF AND type = Bug" --days 14 --bot "Jira Automation" -o markdown`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		days, _ := cmd.Flags().GetInt("days")
+		extraBots, _ := cmd.Flags().GetStringSlice("bot")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+		categories := loadStatusCategories(jiraURL, apikey)
+		bots := append(viper.GetStringSlice("bots"), extraBots...)
+
+		report, err := lib.FetchStaleReport(jiraURL, apikey, args[0], categories, days, bots, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(staleReportCmd)
+
+	addOutputFlag(staleReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	staleReportCmd.Flags().Int("days", lib.DefaultStaleDays, "Days without human activity after which an open issue is stale")
+	staleReportCmd.Flags().StringSlice("bot", nil, "Author to ignore in addition to the bots in the config; repeat for several authors")
+}
@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStaleReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "stale [jql]", staleReportCmd.Use)
+
+	outputFlag := staleReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "30", staleReportCmd.Flags().Lookup("days").DefValue)
+	assert.Equal(t, "[]", staleReportCmd.Flags().Lookup("bot").DefValue)
+	assert.Error(t, staleReportCmd.Args(staleReportCmd, []string{}))
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var standupReportCmd = &cobra.Command{
+	Use:   "standup [users...]",
+	Short: "Summarize what each person moved to Done, transitioned, commented on, and what is blocked",
+	Long: `Build a standup digest from the activity of each user since a date. For every person
+it lists the issues they moved to a done status, the other issues they transitioned, the
+issues they commented on, and their unresolved issues that are blocked.
+
+An issue is blocked when its status is one of --blocked-status, or when the field named
+by --flagged-field (the Jira Software "Flagged" field by default) is set. No status is
+treated as blocked by default, since Jira rejects queries naming a status the instance
+does not have. Pass an empty --flagged-field on instances without that field.
+
+--since accepts the same forms as get userupdates, such as yesterday, -2d or last-week.
+The default Markdown output is ready to paste into chat.
+
+Examples:
+  jiracrawler report standup --team cnf
+  jiracrawler report standup --team cnf --since -3d --blocked-status Blocked --blocked-status "On Hold"
+  jiracrawler report standup user@example.com --since yesterday -o json`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if team, _ := cmd.Flags().GetString("team"); team != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		teamName, _ := cmd.Flags().GetString("team")
+		since, _ := cmd.Flags().GetString("since")
+		blockedStatuses, _ := cmd.Flags().GetStringSlice("blocked-status")
+		flaggedField, _ := cmd.Flags().GetString("flagged-field")
+
+		apikey, jiraURL, jiraUser := validateConfig()
+		applyInstanceConfig()
+
+		users := args
+		if teamName != "" {
+			users = lookupTeam(teamName).Members
+		}
+		users = resolveUserArgs(cmd, jiraURL, apikey, users)
+
+		report, err := lib.FetchStandup(jiraURL, jiraUser, apikey, users, since, blockedStatuses, flaggedField, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		report.Team = teamName
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(standupReportCmd)
+
+	addOutputFlag(standupReportCmd, "markdown", "json", "yaml", "table", "markdown")
+	standupReportCmd.Flags().String("since", "yesterday", "Start of the standup period")
+	standupReportCmd.Flags().StringSlice("blocked-status", lib.DefaultBlockedStatuses, "Status treated as blocked; repeat for several statuses")
+	standupReportCmd.Flags().String("flagged-field", lib.DefaultFlaggedField, "JQL name of the field flagging impediments; empty to ignore flags")
+	addResolveFlag(standupReportCmd)
+	addTeamFlag(standupReportCmd)
+}
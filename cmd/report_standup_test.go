@@ -0,0 +1,25 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStandupReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "standup [users...]", standupReportCmd.Use)
+
+	outputFlag := standupReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "markdown", outputFlag.DefValue)
+
+	assert.Equal(t, "yesterday", standupReportCmd.Flags().Lookup("since").DefValue)
+	assert.Equal(t, "[]", standupReportCmd.Flags().Lookup("blocked-status").DefValue)
+	assert.Equal(t, "Flagged", standupReportCmd.Flags().Lookup("flagged-field").DefValue)
+	assert.NotNil(t, standupReportCmd.Flags().Lookup("team"))
+	assert.NotNil(t, standupReportCmd.Flags().Lookup("no-resolve"))
+
+	assert.Error(t, standupReportCmd.Args(standupReportCmd, []string{}))
+	assert.NoError(t, standupReportCmd.Args(standupReportCmd, []string{"jdoe"}))
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "report", reportCmd.Use)
+	assert.Equal(t, "Generate reports from Jira data", reportCmd.Short)
+
+	verboseFlag := reportCmd.PersistentFlags().Lookup("verbose")
+	assert.NotNil(t, verboseFlag)
+	assert.Equal(t, "v", verboseFlag.Shorthand)
+}
+
+func TestSprintReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "sprint [sprint-id]", sprintReportCmd.Use)
+
+	outputFlag := sprintReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.NotNil(t, sprintReportCmd.Flags().Lookup("scope-jql"))
+}
+
+func TestReportCmdHasSubcommands(t *testing.T) {
+	names := make([]string, 0)
+	for _, cmd := range reportCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "sprint")
+}
+
+func TestPrintOutput_Markdown(t *testing.T) {
+	err := printOutput("markdown", &lib.QueryResult{JQL: "project = CNF"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var throughputReportCmd = &cobra.Command{
+	Use:   "throughput [jql]",
+	Short: "Report the number of issues resolved per day, week or month",
+	Long: `Count the issues matching a JQL query that were resolved in each interval.
+
+Intervals are days, ISO weeks or calendar months in the configured timezone. Intervals
+without resolved issues are included so the series can be plotted directly. Without
+--from and --to the series spans from the first to the last resolution.
+
+Use -o csv or -o json for data suitable for plotting, or -o svg for a bar chart.
+
+Examples:
+  jiracrawler report throughput "project = CNF AND resolved >= -90d" -o table
+  jiracrawler report throughput "project = CNF" --interval month --from 2024-Q1 --to 2024-Q2 -o csv
+  jiracrawler report throughput "project = CNF AND resolved >= -12w" -o svg > throughput.svg`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		interval, _ := cmd.Flags().GetString("interval")
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		report, err := lib.FetchThroughputReport(jiraURL, apikey, args[0], interval, from, to)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(throughputReportCmd)
+
+	addOutputFlag(throughputReportCmd, "json", "json", "yaml", "table", "markdown", "csv", "svg")
+	throughputReportCmd.Flags().String("interval", lib.IntervalWeek, "Interval: day|week|month")
+	throughputReportCmd.Flags().String("from", "", "Start date (YYYY-MM-DD, -90d, 2024-Q1, ...)")
+	throughputReportCmd.Flags().String("to", "", "End date (YYYY-MM-DD, today, 2024-Q2, ...)")
+}
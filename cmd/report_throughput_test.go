@@ -0,0 +1,21 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThroughputReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "throughput [jql]", throughputReportCmd.Use)
+
+	outputFlag := throughputReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "week", throughputReportCmd.Flags().Lookup("interval").DefValue)
+	assert.Equal(t, "", throughputReportCmd.Flags().Lookup("from").DefValue)
+	assert.Equal(t, "", throughputReportCmd.Flags().Lookup("to").DefValue)
+	assert.Error(t, throughputReportCmd.Args(throughputReportCmd, []string{}))
+}
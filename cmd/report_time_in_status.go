@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var timeInStatusReportCmd = &cobra.Command{
+	Use:   "time-in-status [jql]",
+	Short: "Report how long issues spent in each status",
+	Long: `Replay the status changes of the issues matching a JQL query to compute how long each
+issue spent in each status, up to now. The report lists the time per issue and status,
+and the median, 85th percentile and maximum time per status across the issues.
+
+Times are measured in calendar time by default. With --business-hours only working time
+counts: working days start at --workday-start and last the configured hours per day,
+on the configured number of days per week starting Monday (see config set
+--hours-per-day and --days-per-week), in the configured timezone.
+
+Examples:
+  jiracrawler report time-in-status "project = CNF AND resolved >= -30d" -o table
+  jiracrawler report time-in-status "sprint = 12345" --business-hours --workday-start 08:30 -o csv`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		business, _ := cmd.Flags().GetBool("business-hours")
+		workdayStart, _ := cmd.Flags().GetString("workday-start")
+
+		start, err := parseClock(workdayStart)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		report, err := lib.FetchTimeInStatus(jiraURL, apikey, args[0], business, start, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// parseClock parses a time of day such as 09:00 into its offset from midnight
+func parseClock(value string) (time.Duration, error) {
+	t, err := time.Parse("15:04", value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid time of day %q, expected HH:MM", value)
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, nil
+}
+
+func init() {
+	reportCmd.AddCommand(timeInStatusReportCmd)
+
+	addOutputFlag(timeInStatusReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	timeInStatusReportCmd.Flags().Bool("business-hours", false, "Measure time in business hours instead of calendar time")
+	timeInStatusReportCmd.Flags().String("workday-start", "09:00", "Time of day business hours start")
+}
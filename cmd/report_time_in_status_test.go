@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeInStatusReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "time-in-status [jql]", timeInStatusReportCmd.Use)
+
+	outputFlag := timeInStatusReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Equal(t, "false", timeInStatusReportCmd.Flags().Lookup("business-hours").DefValue)
+	assert.Equal(t, "09:00", timeInStatusReportCmd.Flags().Lookup("workday-start").DefValue)
+
+	assert.Error(t, timeInStatusReportCmd.Args(timeInStatusReportCmd, []string{}))
+}
+
+func TestParseClock(t *testing.T) {
+	d, err := parseClock("08:30")
+	assert.NoError(t, err)
+	assert.Equal(t, 8*time.Hour+30*time.Minute, d)
+
+	_, err = parseClock("8am")
+	assert.Error(t, err)
+}
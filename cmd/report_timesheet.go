@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var timesheetReportCmd = &cobra.Command{
+	Use:   "timesheet [users...] [start-date] [end-date]",
+	Short: "Total time logged per user, per day, per issue",
+	Long: `Fetch the work logs of the given users and total the time logged per user, per day,
+per issue within the date range. Both dates are inclusive.
+
+Dates accept the same forms as get userupdates, such as YYYY-MM-DD, -7d, last-week or
+2024-Q1. With --team, only the dates are given and the work logs of every team member
+are totaled.
+
+Examples:
+  jiracrawler report timesheet user@example.com 2024-01-01 2024-01-31 -o table
+  jiracrawler report timesheet user1@example.com user2@example.com 2024-01-01 2024-01-31 -o csv > timesheet.csv
+  jiracrawler report timesheet --team cnf 2024-01-01 2024-01-31 -o table`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if team, _ := cmd.Flags().GetString("team"); team != "" {
+			return cobra.ExactArgs(2)(cmd, args)
+		}
+		return cobra.MinimumNArgs(3)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		teamName, _ := cmd.Flags().GetString("team")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		users := args[:len(args)-2]
+		if teamName != "" {
+			users = lookupTeam(teamName).Members
+		}
+		users = resolveUserArgs(cmd, jiraURL, apikey, users)
+		startDate := args[len(args)-2]
+		endDate := args[len(args)-1]
+
+		report, err := lib.FetchTimesheet(jiraURL, apikey, users, startDate, endDate, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(timesheetReportCmd)
+
+	addOutputFlag(timesheetReportCmd, "json", "json", "yaml", "table", "csv")
+	addResolveFlag(timesheetReportCmd)
+	addTeamFlag(timesheetReportCmd)
+}
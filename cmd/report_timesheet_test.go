@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimesheetReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "timesheet [users...] [start-date] [end-date]", timesheetReportCmd.Use)
+
+	outputFlag := timesheetReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	assert.Error(t, timesheetReportCmd.Args(timesheetReportCmd, []string{"2024-01-01", "2024-01-31"}))
+}
+
+func TestPrintOutput_CSV(t *testing.T) {
+	err := printOutput("csv", &lib.TimesheetReport{})
+	assert.NoError(t, err)
+}
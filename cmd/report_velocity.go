@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var velocityReportCmd = &cobra.Command{
+	Use:   "velocity",
+	Short: "Report committed vs completed story points across recent closed sprints",
+	Long: `Show committed and completed story points for the last N closed sprints of a board,
+with a rolling average of completed points and the standard deviation across sprints.
+
+Table output includes an ASCII bar chart of committed (=) and completed (#) points.
+
+Examples:
+  jiracrawler report velocity --board 1234 --sprints 6 -o table
+  jiracrawler report velocity --board 1234 --sprints 10 --window 5 -o json`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		boardID, _ := cmd.Flags().GetInt("board")
+		count, _ := cmd.Flags().GetInt("sprints")
+		window, _ := cmd.Flags().GetInt("window")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		report, err := lib.FetchVelocityReport(jiraURL, apikey, boardID, count, window, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(velocityReportCmd)
+
+	addOutputFlag(velocityReportCmd, "json", "json", "yaml", "table", "markdown")
+	velocityReportCmd.Flags().IntP("board", "b", 0, "Agile board ID")
+	velocityReportCmd.Flags().Int("sprints", 6, "Number of most recent closed sprints to include")
+	velocityReportCmd.Flags().Int("window", 3, "Number of sprints in the rolling average")
+	_ = velocityReportCmd.MarkFlagRequired("board")
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVelocityReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "velocity", velocityReportCmd.Use)
+
+	boardFlag := velocityReportCmd.Flags().Lookup("board")
+	assert.NotNil(t, boardFlag)
+	assert.Equal(t, "b", boardFlag.Shorthand)
+
+	sprintsFlag := velocityReportCmd.Flags().Lookup("sprints")
+	assert.NotNil(t, sprintsFlag)
+	assert.Equal(t, "6", sprintsFlag.DefValue)
+
+	windowFlag := velocityReportCmd.Flags().Lookup("window")
+	assert.NotNil(t, windowFlag)
+	assert.Equal(t, "3", windowFlag.DefValue)
+}
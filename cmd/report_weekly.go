@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var weeklyReportCmd = &cobra.Command{
+	Use:   "weekly [users...]",
+	Short: "Generate a weekly status report from a Go template",
+	Long: `Collect the issues the users completed, created, have in progress and have blocked
+during a week, grouped per user and per epic, and render them with a Go template.
+
+--week accepts the same forms as get userupdates, such as 2024-W03, last-week or
+this-week, and covers that whole period. Completed and created issues are those resolved
+or created within the week; in progress and blocked issues are the current ones.
+
+Markdown and HTML output use a built-in template unless --template names a template
+file. Markdown templates run on text/template and HTML templates on html/template; the
+data model is described in docs/cli-reference.md. JSON and YAML output print the data
+model itself, which is useful when writing templates, and cannot be combined with
+--template.
+
+Examples:
+  jiracrawler report weekly --team cnf --week 2024-W03
+  jiracrawler report weekly --team cnf --week last-week --template report.md.tmpl > status.md
+  jiracrawler report weekly user@example.com --week this-week -o html > status.html`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if team, _ := cmd.Flags().GetString("team"); team != "" {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.MinimumNArgs(1)(cmd, args)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		verbose, _ := cmd.Flags().GetBool("verbose")
+		teamName, _ := cmd.Flags().GetString("team")
+		week, _ := cmd.Flags().GetString("week")
+		templatePath, _ := cmd.Flags().GetString("template")
+		blockedStatuses, _ := cmd.Flags().GetStringSlice("blocked-status")
+		flaggedField, _ := cmd.Flags().GetString("flagged-field")
+
+		if err := checkWeeklyTemplate(output, templatePath); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		var templateText string
+		if templatePath != "" {
+			data, err := os.ReadFile(templatePath)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error: reading template: %v\n", err)
+				os.Exit(1)
+			}
+			templateText = string(data)
+		}
+
+		apikey, jiraURL, _ := validateConfig()
+		applyInstanceConfig()
+
+		users := args
+		if teamName != "" {
+			users = lookupTeam(teamName).Members
+		}
+		users = resolveUserArgs(cmd, jiraURL, apikey, users)
+
+		report, err := lib.FetchWeeklyReport(jiraURL, apikey, users, week, blockedStatuses, flaggedField, verbose)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		report.Team = teamName
+
+		switch output {
+		case "markdown", "md", "html":
+			err = lib.RenderWeeklyReport(os.Stdout, report, output, templateText)
+		default:
+			err = printOutput(output, report)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// checkWeeklyTemplate rejects --template for output formats that do not use a template
+func checkWeeklyTemplate(output, templatePath string) error {
+	switch output {
+	case "markdown", "md", "html":
+		return nil
+	}
+	if templatePath != "" {
+		return fmt.Errorf("--template only applies to markdown and html output, not %s", output)
+	}
+	return nil
+}
+
+func init() {
+	reportCmd.AddCommand(weeklyReportCmd)
+
+	addOutputFlag(weeklyReportCmd, "markdown", "json", "yaml", "markdown", "html")
+	weeklyReportCmd.Flags().String("week", "this-week", "Week to report on")
+	weeklyReportCmd.Flags().String("template", "", "Go template file used for markdown or html output")
+	weeklyReportCmd.Flags().StringSlice("blocked-status", lib.DefaultBlockedStatuses, "Status treated as blocked; repeat for several statuses")
+	weeklyReportCmd.Flags().String("flagged-field", lib.DefaultFlaggedField, "JQL name of the field flagging impediments; empty to ignore flags")
+	addResolveFlag(weeklyReportCmd)
+	addTeamFlag(weeklyReportCmd)
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeeklyReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "weekly [users...]", weeklyReportCmd.Use)
+
+	outputFlag := weeklyReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "markdown", outputFlag.DefValue)
+
+	assert.Equal(t, "this-week", weeklyReportCmd.Flags().Lookup("week").DefValue)
+	assert.NotNil(t, weeklyReportCmd.Flags().Lookup("template"))
+	assert.NotNil(t, weeklyReportCmd.Flags().Lookup("blocked-status"))
+	assert.NotNil(t, weeklyReportCmd.Flags().Lookup("flagged-field"))
+	assert.NotNil(t, weeklyReportCmd.Flags().Lookup("team"))
+
+	assert.Error(t, weeklyReportCmd.Args(weeklyReportCmd, []string{}))
+}
+
+func TestCheckWeeklyTemplate(t *testing.T) {
+	assert.NoError(t, checkWeeklyTemplate("markdown", "report.md.tmpl"))
+	assert.NoError(t, checkWeeklyTemplate("html", "report.html.tmpl"))
+	assert.NoError(t, checkWeeklyTemplate("json", ""))
+	assert.Error(t, checkWeeklyTemplate("json", "report.md.tmpl"))
+	assert.Error(t, checkWeeklyTemplate("yaml", "report.md.tmpl"))
+}
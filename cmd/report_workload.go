@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var workloadReportCmd = &cobra.Command{
+	Use:   "workload",
+	Short: "Compare the open work assigned to the members of a team",
+	Long: `Show the open issues assigned to each member of a team defined in the config, by priority
+and issue type, with their remaining estimate and their work in progress: the open issues
+in a status of the "in progress" category.
+
+Members with more open issues, work in progress or remaining estimate than the team mean
+by more than --deviation are flagged; 0.5 flags members more than 50% above the mean.
+
+The team's default project is used unless --projectID is given. Status categories are read
+from Jira and can be overridden in the status_categories section of the config.
+
+Examples:
+  jiracrawler report workload --team cnf -o table
+  jiracrawler report workload --team cnf --projectID CNFCERT --deviation 0.25 -o markdown`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		projectID, _ := cmd.Flags().GetString("projectID")
+		teamName, _ := cmd.Flags().GetString("team")
+		deviation, _ := cmd.Flags().GetFloat64("deviation")
+		if deviation < 0 {
+			fmt.Fprintln(os.Stderr, "Error: --deviation must not be negative")
+			os.Exit(1)
+		}
+
+		apikey, jiraURL, jiraUser := validateConfig()
+		applyInstanceConfig()
+		team := lookupTeam(teamName)
+		if team.Project != "" && !cmd.Flags().Changed("projectID") {
+			projectID = team.Project
+		}
+		team.Members = resolveUserArgs(cmd, jiraURL, apikey, team.Members)
+		categories := loadStatusCategories(jiraURL, apikey)
+
+		report, err := lib.FetchTeamWorkload(jiraURL, jiraUser, apikey, projectID, team, categories, deviation)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	reportCmd.AddCommand(workloadReportCmd)
+
+	addOutputFlag(workloadReportCmd, "json", "json", "yaml", "table", "markdown", "csv")
+	workloadReportCmd.Flags().StringP("projectID", "p", "CNF", "Jira project key (e.g., CNF)")
+	workloadReportCmd.Flags().Float64("deviation", lib.DefaultWorkloadDeviation, "Flag members above the team mean by more than this share of it")
+	addTeamFlag(workloadReportCmd)
+	addResolveFlag(workloadReportCmd)
+	_ = workloadReportCmd.MarkFlagRequired("team")
+}
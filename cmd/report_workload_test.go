@@ -0,0 +1,26 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkloadReportCmdStructure(t *testing.T) {
+	assert.Equal(t, "workload", workloadReportCmd.Use)
+
+	outputFlag := workloadReportCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "o", outputFlag.Shorthand)
+	assert.Equal(t, "json", outputFlag.DefValue)
+
+	projectFlag := workloadReportCmd.Flags().Lookup("projectID")
+	assert.NotNil(t, projectFlag)
+	assert.Equal(t, "p", projectFlag.Shorthand)
+	assert.Equal(t, "CNF", projectFlag.DefValue)
+
+	assert.Equal(t, "0.5", workloadReportCmd.Flags().Lookup("deviation").DefValue)
+	assert.NotNil(t, workloadReportCmd.Flags().Lookup("team"))
+	assert.NotNil(t, workloadReportCmd.Flags().Lookup("no-resolve"))
+	assert.Error(t, workloadReportCmd.Args(workloadReportCmd, []string{"extra"}))
+}
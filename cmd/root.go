@@ -19,6 +19,7 @@ func Execute() error {
 	cobra.OnInitialize(initConfig)
 	rootCmd.AddCommand(configCmd)
 	rootCmd.AddCommand(getCmd)
+	registerCompletions(rootCmd)
 	return rootCmd.Execute()
 }
 
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// loadTeams reads the teams section of the config, keyed by team name.
+func loadTeams() (map[string]lib.Team, error) {
+	var raw map[string]struct {
+		Project string
+		Members []string
+	}
+	if err := viper.UnmarshalKey("teams", &raw); err != nil {
+		return nil, fmt.Errorf("reading teams from config: %w", err)
+	}
+
+	teams := make(map[string]lib.Team, len(raw))
+	for name, t := range raw {
+		teams[name] = lib.Team{Name: name, Project: t.Project, Members: t.Members}
+	}
+	return teams, nil
+}
+
+// lookupTeam returns the team with the given name from the config, exiting if it is
+// missing or has no members. Names are matched case-insensitively since viper lowercases
+// the keys of the teams section.
+func lookupTeam(name string) lib.Team {
+	teams, err := loadTeams()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	team, ok := teams[strings.ToLower(name)]
+	if !ok {
+		fmt.Fprintf(os.Stderr, "Error: team %q is not defined in the config\n", name)
+		os.Exit(1)
+	}
+	if err := team.Validate(); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return team
+}
+
+// addTeamFlag registers the --team flag on commands that take users.
+func addTeamFlag(cmd *cobra.Command) {
+	cmd.Flags().String("team", "", "Use the members of a team defined in the config instead of user arguments")
+}
+
+// completeTeamNames completes the names of the teams defined in the config
+func completeTeamNames(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+	teams, err := loadTeams()
+	if err != nil {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+
+	names := make([]string, 0, len(teams))
+	for name := range teams {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadTeams(t *testing.T) {
+	viper.Set("teams", map[string]interface{}{
+		"cnf": map[string]interface{}{
+			"project": "CNF",
+			"members": []string{"alice@example.com", "bob@example.com"},
+		},
+	})
+	defer viper.Set("teams", nil)
+
+	teams, err := loadTeams()
+	assert.NoError(t, err)
+	assert.Equal(t, "cnf", teams["cnf"].Name)
+	assert.Equal(t, "CNF", teams["cnf"].Project)
+	assert.Equal(t, []string{"alice@example.com", "bob@example.com"}, teams["cnf"].Members)
+
+	names, _ := completeTeamNames(assignedIssuesCmd, nil, "")
+	assert.Equal(t, []string{"cnf"}, names)
+}
+
+func TestLookupTeam_MixedCase(t *testing.T) {
+	viper.Set("teams", map[string]interface{}{
+		"CNF-Core": map[string]interface{}{
+			"project": "CNF",
+			"members": []string{"alice@example.com"},
+		},
+	})
+	defer viper.Set("teams", nil)
+
+	team := lookupTeam("CNF-Core")
+	assert.Equal(t, "CNF", team.Project)
+	assert.Equal(t, []string{"alice@example.com"}, team.Members)
+	assert.Equal(t, "CNF", lookupTeam("cnf-core").Project)
+}
+
+func TestTeamFlag(t *testing.T) {
+	for _, c := range []*cobra.Command{assignedIssuesCmd, userUpdatesCmd, timesheetReportCmd} {
+		assert.NotNil(t, c.Flags().Lookup("team"), "%s is missing --team", c.Name())
+	}
+}
+
+func TestUserUpdatesArgs_Team(t *testing.T) {
+	assert.Error(t, userUpdatesCmd.Args(userUpdatesCmd, []string{"2024-01-01", "2024-01-31"}))
+	assert.NoError(t, userUpdatesCmd.Args(userUpdatesCmd, []string{"user@example.com", "2024-01-01", "2024-01-31"}))
+
+	assert.NoError(t, userUpdatesCmd.Flags().Set("team", "cnf"))
+	defer func() { _ = userUpdatesCmd.Flags().Set("team", "") }()
+	assert.NoError(t, userUpdatesCmd.Args(userUpdatesCmd, []string{"2024-01-01", "2024-01-31"}))
+}
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Look up Jira users",
+}
+
+var userSearchCmd = &cobra.Command{
+	Use:   "search [text]",
+	Short: "Search Jira users by username, name or email",
+	Long: `Search Jira users whose username, name or email starts with the given text.
+
+Use this to find the identifier to pass to commands that take users.
+
+Example:
+  jiracrawler user search jdoe -o table`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+		maxResults, _ := cmd.Flags().GetInt("max-results")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		users, err := lib.SearchUsers(jiraURL, apikey, args[0], maxResults)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, &lib.UserSearchResult{Query: args[0], Users: users}); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// addResolveFlag registers the --no-resolve flag on commands that take users.
+func addResolveFlag(cmd *cobra.Command) {
+	cmd.Flags().Bool("no-resolve", false, "Use user arguments as given instead of resolving them through the user search API")
+}
+
+// resolveUserArgs resolves user arguments to Jira identifiers unless --no-resolve is set.
+// Cloud account IDs are passed through. It exits when a user matches nobody or more than
+// one Jira user.
+func resolveUserArgs(cmd *cobra.Command, jiraURL, apikey string, users []string) []string {
+	if noResolve, _ := cmd.Flags().GetBool("no-resolve"); noResolve {
+		return users
+	}
+
+	resolved, err := lib.ResolveUsers(jiraURL, apikey, users)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return resolved
+}
+
+func init() {
+	rootCmd.AddCommand(userCmd)
+	userCmd.AddCommand(userSearchCmd)
+
+	addOutputFlag(userSearchCmd, "json", "json", "yaml", "table")
+	userSearchCmd.Flags().IntP("max-results", "m", 20, "Maximum number of users to return")
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUserSearchCmdStructure(t *testing.T) {
+	assert.Equal(t, "search [text]", userSearchCmd.Use)
+
+	names := make([]string, 0)
+	for _, cmd := range userCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "search")
+
+	maxResultsFlag := userSearchCmd.Flags().Lookup("max-results")
+	assert.NotNil(t, maxResultsFlag)
+	assert.Equal(t, "20", maxResultsFlag.DefValue)
+}
+
+func TestNoResolveFlag(t *testing.T) {
+	for _, c := range []*cobra.Command{assignedIssuesCmd, userUpdatesCmd, timesheetReportCmd} {
+		flag := c.Flags().Lookup("no-resolve")
+		assert.NotNil(t, flag, "%s is missing --no-resolve", c.Name())
+		assert.Equal(t, "false", flag.DefValue)
+	}
+}
+
+func TestResolveUserArgs_NoResolve(t *testing.T) {
+	assert.NoError(t, assignedIssuesCmd.Flags().Set("no-resolve", "true"))
+	defer func() { _ = assignedIssuesCmd.Flags().Set("no-resolve", "false") }()
+
+	users := resolveUserArgs(assignedIssuesCmd, "", "", []string{"typo@example.com"})
+	assert.Equal(t, []string{"typo@example.com"}, users)
+}
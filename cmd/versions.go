@@ -0,0 +1,43 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/sebrandon1/jiracrawler/lib"
+	"github.com/spf13/cobra"
+)
+
+var versionsCmd = &cobra.Command{
+	Use:   "versions [project]",
+	Short: "List the versions of a project",
+	Long: `List the versions of a project with their release status, release date and the
+number of unresolved issues in each version.
+
+Example:
+  jiracrawler get versions CNF -o table`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeProjectKeyArg,
+	Run: func(cmd *cobra.Command, args []string) {
+		output, _ := cmd.Flags().GetString("output")
+
+		apikey, jiraURL, _ := validateConfig()
+
+		result, err := lib.FetchProjectVersions(jiraURL, apikey, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+
+		if err := printOutput(output, result); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	getCmd.AddCommand(versionsCmd)
+
+	addOutputFlag(versionsCmd, "json", "json", "yaml", "table")
+}
@@ -0,0 +1,32 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionsCmdStructure(t *testing.T) {
+	assert.Equal(t, "versions [project]", versionsCmd.Use)
+
+	outputFlag := versionsCmd.Flags().Lookup("output")
+	assert.NotNil(t, outputFlag)
+	assert.Equal(t, "json", outputFlag.DefValue)
+}
+
+func TestVersionsCmdRegistered(t *testing.T) {
+	names := make([]string, 0)
+	for _, cmd := range getCmd.Commands() {
+		names = append(names, cmd.Name())
+	}
+	assert.Contains(t, names, "versions")
+}
+
+func TestIssueListFlags(t *testing.T) {
+	for _, c := range []*cobra.Command{assignedIssuesCmd, userUpdatesCmd, queryCmd} {
+		for _, name := range []string{"columns", "fix-version", "affects-version", "min-watches", "min-votes"} {
+			assert.NotNil(t, c.Flags().Lookup(name), "%s is missing --%s", c.Name(), name)
+		}
+	}
+}
@@ -0,0 +1,259 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Activity actions recorded in a user timeline
+const (
+	ActivityCreated      = "created"
+	ActivityCommented    = "commented"
+	ActivityTransitioned = "transitioned"
+	ActivityAssigned     = "assigned"
+	ActivityUpdated      = "updated"
+)
+
+// activityDetailLength is the number of characters of a comment kept in an activity event
+const activityDetailLength = 80
+
+// ActivityEvent is one concrete action a user took on an issue
+type ActivityEvent struct {
+	At       time.Time `json:"at" yaml:"at"`
+	IssueKey string    `json:"issueKey" yaml:"issueKey"`
+	Summary  string    `json:"summary" yaml:"summary"`
+	Action   string    `json:"action" yaml:"action"`
+	From     string    `json:"from,omitempty" yaml:"from,omitempty"`
+	To       string    `json:"to,omitempty" yaml:"to,omitempty"`
+	Detail   string    `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// UserActivity is the timeline of actions one user took within a date range
+type UserActivity struct {
+	User   string          `json:"user" yaml:"user"`
+	Issues int             `json:"issues" yaml:"issues"`
+	Events []ActivityEvent `json:"events" yaml:"events"`
+}
+
+// ActivityReport holds the activity timelines of several users
+type ActivityReport struct {
+	DateRange string         `json:"dateRange" yaml:"dateRange"`
+	Users     []UserActivity `json:"users" yaml:"users"`
+}
+
+// authoredBy reports whether an author, given by display name and identifier, is the user
+func authoredBy(displayName, name, user string) bool {
+	return (name != "" && strings.EqualFold(name, user)) ||
+		(displayName != "" && strings.EqualFold(displayName, user))
+}
+
+// BuildUserActivity extracts the actions the user took on the issues within the date range
+// from their creation, comments and change history, in chronological order
+func BuildUserActivity(user string, issues []Issue, dateRange DateRange) UserActivity {
+	activity := UserActivity{User: user}
+	touched := make(map[string]bool)
+	add := func(issue Issue, event ActivityEvent) {
+		event.IssueKey = issue.Key
+		event.Summary = issue.Summary
+		activity.Events = append(activity.Events, event)
+		touched[issue.Key] = true
+	}
+
+	for _, issue := range issues {
+		if created, err := parseJiraTime(issue.Created); err == nil && dateRange.Contains(created) &&
+			(userMatches(issue.Reporter, user) || userMatches(issue.Creator, user)) {
+			add(issue, ActivityEvent{At: created, Action: ActivityCreated})
+		}
+
+		for _, c := range issue.Comments {
+			if dateRange.Contains(c.Created) && authoredBy(c.Author, c.AuthorName, user) {
+				add(issue, ActivityEvent{At: c.Created, Action: ActivityCommented, Detail: commentExcerpt(c.Body)})
+			}
+		}
+
+		for _, h := range issue.History {
+			if !dateRange.Contains(h.Created) || !authoredBy(h.Author, h.AuthorName, user) {
+				continue
+			}
+			var updated []string
+			for _, item := range h.Items {
+				switch strings.ToLower(item.Field) {
+				case "status":
+					add(issue, ActivityEvent{At: h.Created, Action: ActivityTransitioned, From: item.FromString, To: item.ToString})
+				case "assignee":
+					add(issue, ActivityEvent{At: h.Created, Action: ActivityAssigned, From: item.FromString, To: item.ToString})
+				default:
+					updated = append(updated, item.Field)
+				}
+			}
+			if len(updated) > 0 {
+				add(issue, ActivityEvent{At: h.Created, Action: ActivityUpdated, Detail: strings.Join(updated, ", ")})
+			}
+		}
+	}
+
+	sort.SliceStable(activity.Events, func(i, j int) bool {
+		return activity.Events[i].At.Before(activity.Events[j].At)
+	})
+	activity.Issues = len(touched)
+	return activity
+}
+
+// commentExcerpt returns the first line of a comment, shortened for display
+func commentExcerpt(body string) string {
+	line, _, _ := strings.Cut(strings.TrimSpace(body), "\n")
+	if len(line) > activityDetailLength {
+		return line[:activityDetailLength-3] + "..."
+	}
+	return line
+}
+
+// activityJQL selects the issues a user may have touched within the date range. Comments
+// cannot be searched by author, so watched issues stand in for them; Jira adds commenters
+// as watchers by default. issueHistory() only applies to the authenticated user.
+func activityJQL(user string, dateRange DateRange, self bool) string {
+	loc := GetTimezone()
+	during := fmt.Sprintf(`DURING ("%s", "%s")`,
+		dateRange.Start.In(loc).Format(jqlTimeLayout), dateRange.End.In(loc).Format(jqlTimeLayout))
+
+	clauses := []string{
+		fmt.Sprintf(`assignee was "%s" %s`, user, during),
+		fmt.Sprintf(`status changed BY "%s" %s`, user, during),
+		fmt.Sprintf(`assignee changed BY "%s" %s`, user, during),
+		fmt.Sprintf(`reporter = "%s"`, user),
+		fmt.Sprintf(`watcher = "%s"`, user),
+	}
+	if self {
+		clauses = append(clauses, "issuekey in issueHistory()")
+	}
+	return fmt.Sprintf(`(%s) AND updated >= "%s" ORDER BY updated DESC`,
+		strings.Join(clauses, " OR "), dateRange.Start.In(loc).Format(jqlTimeLayout))
+}
+
+// FetchUserActivity builds the activity timeline of each user within the date range by
+// searching for candidate issues and replaying their comments and change history.
+// startDate and endDate accept any form understood by ParseDateRange. The authenticated
+// user is read from Jira once, so a user given by username or account ID is still
+// recognised when jiraUser is an email address.
+func FetchUserActivity(jiraURL, jiraUser, apikey string, users []string, startDate, endDate string, verbose bool) (*ActivityReport, error) {
+	if jiraURL == "" || apikey == "" || len(users) == 0 {
+		return nil, fmt.Errorf("jiraURL, apikey, and users must be provided")
+	}
+
+	dateRange, err := ParseDateRange(startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	// Best-effort: without it only a user given exactly as jiraUser counts as the caller
+	var self *User
+	if me, _, err := client.User.GetSelf(); err == nil {
+		self = convertJiraUser(me)
+	}
+
+	// Issues touched by several users are only fetched once
+	fetched := make(map[string]Issue)
+	report := &ActivityReport{DateRange: dateRange.String()}
+	for _, user := range users {
+		isSelf := strings.EqualFold(user, jiraUser) || userMatches(self, user)
+		candidates, err := searchAllIssues(client, activityJQL(user, dateRange, isSelf))
+		if err != nil {
+			return nil, fmt.Errorf("searching activity of %s: %w", user, err)
+		}
+
+		issues := make([]Issue, 0, len(candidates))
+		for i, issue := range candidates {
+			if cached, ok := fetched[issue.Key]; ok {
+				issues = append(issues, cached)
+				continue
+			}
+			if verbose {
+				fmt.Fprintf(os.Stderr, "Fetching comments and history for %s (%d/%d)...\n", issue.Key, i+1, len(candidates))
+			}
+			if err := fetchIssueActivity(client, jiraURL, apikey, &issue); err != nil {
+				return nil, err
+			}
+			fetched[issue.Key] = issue
+			issues = append(issues, issue)
+		}
+
+		report.Users = append(report.Users, BuildUserActivity(user, issues, dateRange))
+	}
+	return report, nil
+}
+
+// fetchIssueActivity populates the comments and change history of an issue
+func fetchIssueActivity(client *jira.Client, jiraURL, apikey string, issue *Issue) error {
+	rl := GetGlobalRateLimiter()
+
+	rl.Wait()
+	comments, err := FetchIssueComments(client, jiraURL, issue.Key, apikey)
+	if err != nil {
+		return fmt.Errorf("fetching comments for %s: %w", issue.Key, err)
+	}
+	issue.Comments = comments
+
+	rl.Wait()
+	history, err := FetchIssueHistory(client, jiraURL, issue.Key, apikey)
+	if err != nil {
+		return fmt.Errorf("fetching history for %s: %w", issue.Key, err)
+	}
+	issue.History = history
+	return nil
+}
+
+// describe returns a human-readable description of the event
+func (e ActivityEvent) describe() string {
+	switch e.Action {
+	case ActivityTransitioned, ActivityAssigned:
+		return fmt.Sprintf("%s %s → %s", e.Action, valueOrNone(e.From), valueOrNone(e.To))
+	case ActivityCommented:
+		return fmt.Sprintf("commented: %s", e.Detail)
+	case ActivityUpdated:
+		return fmt.Sprintf("updated %s", e.Detail)
+	}
+	return e.Action
+}
+
+func valueOrNone(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
+
+func printActivityTable(w *tabwriter.Writer, report *ActivityReport) {
+	fmt.Fprintln(w, "USER\tTIME\tKEY\tACTION\tSUMMARY")
+	loc := GetTimezone()
+	for _, u := range report.Users {
+		for _, e := range u.Events {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", u.User, e.At.In(loc).Format(jqlTimeLayout), e.IssueKey, e.describe(), truncateSummary(e.Summary))
+		}
+	}
+}
+
+func writeActivityMarkdown(b *strings.Builder, report *ActivityReport) {
+	loc := GetTimezone()
+	for _, u := range report.Users {
+		fmt.Fprintf(b, "## %s (%s)\n\n", escapeMarkdown(u.User), report.DateRange)
+		if len(u.Events) == 0 {
+			b.WriteString("No activity.\n\n")
+			continue
+		}
+		for _, e := range u.Events {
+			fmt.Fprintf(b, "- %s **%s** %s: %s\n", e.At.In(loc).Format(jqlTimeLayout), e.IssueKey, escapeMarkdown(e.describe()), escapeMarkdown(e.Summary))
+		}
+		b.WriteString("\n")
+	}
+}
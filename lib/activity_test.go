@@ -0,0 +1,160 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func activityTestRange() DateRange {
+	return DateRange{
+		Start: time.Date(2024, 1, 15, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2024, 1, 16, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+func TestBuildUserActivity(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 1, 15, hour, 0, 0, 0, time.UTC) }
+	issues := []Issue{
+		{
+			Key:      "TEST-1",
+			Summary:  "Fix login",
+			Created:  "2024-01-15T08:00:00Z",
+			Reporter: &User{Name: "jdoe", DisplayName: "Jane Doe"},
+			Comments: []Comment{
+				{Author: "Jane Doe", AuthorName: "jdoe", Body: "Looking into it\nmore details", Created: at(10)},
+				{Author: "John Smith", AuthorName: "jsmith", Body: "Thanks", Created: at(11)},
+			},
+			History: []HistoryItem{
+				{AuthorName: "jdoe", Created: at(12), Items: []HistoryChange{
+					{Field: "status", FromString: "To Do", ToString: "In Progress"},
+					{Field: "labels", FromString: "", ToString: "backend"},
+				}},
+				{AuthorName: "jdoe", Created: at(13), Items: []HistoryChange{
+					{Field: "assignee", FromString: "Jane Doe", ToString: "John Smith"},
+				}},
+				// Outside the range
+				{AuthorName: "jdoe", Created: at(30), Items: []HistoryChange{{Field: "status", ToString: "Done"}}},
+			},
+		},
+		{
+			Key:     "TEST-2",
+			Created: "2024-01-01T08:00:00Z",
+			History: []HistoryItem{
+				{AuthorName: "jsmith", Created: at(9), Items: []HistoryChange{{Field: "status", ToString: "Done"}}},
+			},
+		},
+	}
+
+	activity := BuildUserActivity("jdoe", issues, activityTestRange())
+	assert.Equal(t, 1, activity.Issues)
+	assert.Len(t, activity.Events, 5)
+
+	actions := make([]string, len(activity.Events))
+	for i, e := range activity.Events {
+		actions[i] = e.Action
+	}
+	assert.Equal(t, []string{ActivityCreated, ActivityCommented, ActivityTransitioned, ActivityUpdated, ActivityAssigned}, actions)
+	assert.Equal(t, "Looking into it", activity.Events[1].Detail)
+	assert.Equal(t, "In Progress", activity.Events[2].To)
+	assert.Equal(t, "labels", activity.Events[3].Detail)
+	assert.Equal(t, "transitioned To Do → In Progress", activity.Events[2].describe())
+}
+
+func TestActivityJQL(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	jql := activityJQL("jdoe", activityTestRange(), false)
+	assert.Contains(t, jql, `assignee was "jdoe" DURING ("2024-01-15 00:00", "2024-01-16 00:00")`)
+	assert.Contains(t, jql, `status changed BY "jdoe"`)
+	assert.Contains(t, jql, `reporter = "jdoe"`)
+	assert.Contains(t, jql, `updated >= "2024-01-15 00:00"`)
+	assert.NotContains(t, jql, "issueHistory()")
+
+	assert.Contains(t, activityJQL("jdoe", activityTestRange(), true), "issuekey in issueHistory()")
+}
+
+func TestFetchUserActivity(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"startAt": 0, "maxResults": 100, "total": 1,
+				"issues": []map[string]interface{}{
+					{"key": "TEST-1", "fields": map[string]interface{}{"summary": "Fix login", "created": "2023-12-01T08:00:00.000+0000"}},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"comments": []map[string]interface{}{
+					{"id": "1", "body": "Done here", "author": map[string]string{"name": "jdoe", "displayName": "Jane Doe"}, "created": "2024-01-15T10:00:00.000+0000"},
+				},
+			})
+		case r.URL.Path == "/rest/api/2/issue/TEST-1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"changelog": map[string]interface{}{
+					"histories": []map[string]interface{}{
+						{"id": "1", "author": map[string]string{"name": "jdoe"}, "created": "2024-01-15T11:00:00.000+0000",
+							"items": []map[string]string{{"field": "status", "fromString": "In Progress", "toString": "Done"}}},
+					},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	report, err := FetchUserActivity(server.URL, "me", "token", []string{"jdoe"}, "2024-01-15", "2024-01-15", false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-01-15 to 2024-01-15", report.DateRange)
+	assert.Len(t, report.Users, 1)
+	assert.Len(t, report.Users[0].Events, 2)
+	assert.Equal(t, ActivityCommented, report.Users[0].Events[0].Action)
+	assert.Equal(t, ActivityTransitioned, report.Users[0].Events[1].Action)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
+
+func TestFetchUserActivity_Self(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	queries := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/myself":
+			_ = json.NewEncoder(w).Encode(map[string]string{"name": "jdoe", "emailAddress": "jdoe@example.com"})
+		case "/rest/api/2/search":
+			jql := r.URL.Query().Get("jql")
+			for _, user := range []string{"jdoe", "asmith"} {
+				if strings.Contains(jql, `reporter = "`+user+`"`) {
+					queries[user] = jql
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"startAt": 0, "maxResults": 100, "total": 0, "issues": []interface{}{}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	// The configured user is an email while the users are resolved usernames
+	_, err := FetchUserActivity(server.URL, "jdoe@example.com", "token", []string{"jdoe", "asmith"}, "2024-01-15", "2024-01-15", false)
+	assert.NoError(t, err)
+	assert.Contains(t, queries["jdoe"], "issuekey in issueHistory()")
+	assert.NotContains(t, queries["asmith"], "issuekey in issueHistory()")
+}
@@ -0,0 +1,164 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Reasons an issue is flagged by the aging report
+const (
+	AgingFlagStatusAge = "status-age"
+	AgingFlagWorkAge   = "work-age"
+	AgingFlagCycleTime = "p85-cycle-time"
+)
+
+// AgingThresholds holds the ages in days past which work in progress is flagged. Zero
+// disables a threshold.
+type AgingThresholds struct {
+	StatusDays float64 `json:"statusDays" yaml:"statusDays"`
+	WorkDays   float64 `json:"workDays" yaml:"workDays"`
+}
+
+// AgingIssue is one unresolved in-progress issue with its ages in days
+type AgingIssue struct {
+	Key           string   `json:"key" yaml:"key"`
+	Summary       string   `json:"summary" yaml:"summary"`
+	Status        string   `json:"status" yaml:"status"`
+	Assignee      string   `json:"assignee" yaml:"assignee"`
+	StatusSince   string   `json:"statusSince" yaml:"statusSince"`
+	Started       string   `json:"started" yaml:"started"`
+	StatusAgeDays float64  `json:"statusAgeDays" yaml:"statusAgeDays"`
+	WorkAgeDays   float64  `json:"workAgeDays" yaml:"workAgeDays"`
+	Flags         []string `json:"flags,omitempty" yaml:"flags,omitempty"`
+}
+
+// AgingReport lists the work in progress, oldest first. CycleTimeP85 is the 85th percentile
+// cycle time of the issues matched by HistoryJQL, or zero when no history was given.
+type AgingReport struct {
+	JQL          string          `json:"jql" yaml:"jql"`
+	HistoryJQL   string          `json:"historyJql,omitempty" yaml:"historyJql,omitempty"`
+	Thresholds   AgingThresholds `json:"thresholds" yaml:"thresholds"`
+	CycleTimeP85 float64         `json:"cycleTimeP85Days,omitempty" yaml:"cycleTimeP85Days,omitempty"`
+	Flagged      int             `json:"flagged" yaml:"flagged"`
+	Issues       []AgingIssue    `json:"issues" yaml:"issues"`
+}
+
+// BuildAgingReport lists the unresolved issues in an in-progress status with the days since
+// they entered their current status and since they first entered an in-progress status.
+// Issues past a threshold, or whose work age exceeds cycleTimeP85 when it is positive, are flagged.
+func BuildAgingReport(issues []Issue, categories StatusCategories, thresholds AgingThresholds, cycleTimeP85 float64, now time.Time) *AgingReport {
+	report := &AgingReport{Thresholds: thresholds, CycleTimeP85: cycleTimeP85, Issues: []AgingIssue{}}
+	days := func(since time.Time) float64 { return now.Sub(since).Hours() / 24 }
+
+	for _, issue := range issues {
+		if issue.Resolved != "" || categories.Category(issue.Status.Name) != CategoryInProgress {
+			continue
+		}
+		periods := StatusPeriods(issue, now)
+		if len(periods) == 0 {
+			continue
+		}
+
+		current := periods[len(periods)-1]
+		started := current.Start
+		for _, p := range periods {
+			if categories.Category(p.Status) == CategoryInProgress {
+				started = p.Start
+				break
+			}
+		}
+
+		ai := AgingIssue{
+			Key:           issue.Key,
+			Summary:       issue.Summary,
+			Status:        issue.Status.Name,
+			Assignee:      userDisplayName(issue.Assignee),
+			StatusSince:   current.Start.Format(time.RFC3339),
+			Started:       started.Format(time.RFC3339),
+			StatusAgeDays: days(current.Start),
+			WorkAgeDays:   days(started),
+		}
+		if thresholds.StatusDays > 0 && ai.StatusAgeDays > thresholds.StatusDays {
+			ai.Flags = append(ai.Flags, AgingFlagStatusAge)
+		}
+		if thresholds.WorkDays > 0 && ai.WorkAgeDays > thresholds.WorkDays {
+			ai.Flags = append(ai.Flags, AgingFlagWorkAge)
+		}
+		if cycleTimeP85 > 0 && ai.WorkAgeDays > cycleTimeP85 {
+			ai.Flags = append(ai.Flags, AgingFlagCycleTime)
+		}
+		if len(ai.Flags) > 0 {
+			report.Flagged++
+		}
+		report.Issues = append(report.Issues, ai)
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return report.Issues[i].WorkAgeDays > report.Issues[j].WorkAgeDays
+	})
+	return report
+}
+
+// FetchAgingReport fetches the issues matching the JQL query with their history and reports
+// the age of the work in progress. When historyJQL is set, the issues it matches are used to
+// compute the historical 85th percentile cycle time. See BuildAgingReport.
+func FetchAgingReport(jiraURL, apikey, jql, historyJQL string, categories StatusCategories, thresholds AgingThresholds, verbose bool) (*AgingReport, error) {
+	issues, err := FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	var cycleTimeP85 float64
+	if historyJQL != "" {
+		resolved, err := FetchIssuesWithHistory(jiraURL, apikey, historyJQL, verbose)
+		if err != nil {
+			return nil, fmt.Errorf("fetching history: %w", err)
+		}
+		cycleTimeP85 = BuildFlowReport(resolved, categories, 0).CycleTime.P85
+	}
+
+	report := BuildAgingReport(issues, categories, thresholds, cycleTimeP85, time.Now())
+	report.JQL = jql
+	report.HistoryJQL = historyJQL
+	return report, nil
+}
+
+// formatAgingFlags joins the flags of an issue, or returns "-" when it has none
+func formatAgingFlags(flags []string) string {
+	if len(flags) == 0 {
+		return "-"
+	}
+	return strings.Join(flags, ", ")
+}
+
+func printAgingTable(w *tabwriter.Writer, report *AgingReport) {
+	fmt.Fprintln(w, "KEY\tSTATUS\tASSIGNEE\tDAYS IN STATUS\tDAYS IN PROGRESS\tFLAGS\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.1f\t%.1f\t%s\t%s\n", issue.Key, issue.Status, issue.Assignee,
+			issue.StatusAgeDays, issue.WorkAgeDays, formatAgingFlags(issue.Flags), truncateSummary(issue.Summary))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Flagged:\t%d of %d\n", report.Flagged, len(report.Issues))
+	if report.CycleTimeP85 > 0 {
+		fmt.Fprintf(w, "P85 cycle time:\t%.1f days\n", report.CycleTimeP85)
+	}
+}
+
+func writeAgingMarkdown(b *strings.Builder, report *AgingReport) {
+	b.WriteString("# Aging work in progress\n\n")
+	fmt.Fprintf(b, "%d of %d issues flagged", report.Flagged, len(report.Issues))
+	if report.CycleTimeP85 > 0 {
+		fmt.Fprintf(b, " (historical P85 cycle time: %.1f days)", report.CycleTimeP85)
+	}
+	b.WriteString(".\n\n")
+	b.WriteString("| Key | Status | Assignee | Days in status | Days in progress | Flags | Summary |\n")
+	b.WriteString("|-----|--------|----------|----------------|------------------|-------|---------|\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(b, "| %s | %s | %s | %.1f | %.1f | %s | %s |\n", issue.Key, escapeMarkdown(issue.Status),
+			escapeMarkdown(issue.Assignee), issue.StatusAgeDays, issue.WorkAgeDays,
+			formatAgingFlags(issue.Flags), escapeMarkdown(issue.Summary))
+	}
+}
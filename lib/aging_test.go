@@ -0,0 +1,53 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildAgingReport(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	status := func(at time.Time, from, to string) HistoryItem {
+		return HistoryItem{Created: at, Items: []HistoryChange{{Field: "status", FromString: from, ToString: to}}}
+	}
+	issues := []Issue{
+		{
+			// Started on the 2nd, in review since the 8th
+			Key: "TEST-1", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "Review"},
+			Assignee: &User{Name: "alice", DisplayName: "Alice"},
+			History: []HistoryItem{
+				status(day(2), "New", "Coding"),
+				status(day(8), "Coding", "Review"),
+			},
+		},
+		{
+			Key: "TEST-2", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "Coding"},
+			History: []HistoryItem{status(day(9), "New", "Coding")},
+		},
+		{Key: "TEST-3", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "New"}},
+		{Key: "TEST-4", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "Coding"}, Resolved: "2024-01-05T00:00:00Z"},
+	}
+	categories := StatusCategories{"coding": CategoryInProgress, "review": CategoryInProgress}
+
+	report := BuildAgingReport(issues, categories, AgingThresholds{StatusDays: 2, WorkDays: 10}, 7, day(11))
+	assert.Len(t, report.Issues, 2)
+
+	first := report.Issues[0]
+	assert.Equal(t, "TEST-1", first.Key)
+	assert.Equal(t, "Alice", first.Assignee)
+	assert.Equal(t, 3.0, first.StatusAgeDays)
+	assert.Equal(t, 9.0, first.WorkAgeDays)
+	assert.Equal(t, []string{AgingFlagStatusAge, AgingFlagCycleTime}, first.Flags)
+
+	second := report.Issues[1]
+	assert.Equal(t, "Unassigned", second.Assignee)
+	assert.Equal(t, 2.0, second.WorkAgeDays)
+	assert.Empty(t, second.Flags)
+	assert.Equal(t, 1, report.Flagged)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
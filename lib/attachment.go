@@ -0,0 +1,220 @@
+package lib
+
+import (
+	"bufio"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// DefaultMaxAttachmentSize is the largest attachment downloaded unless overridden (100 MiB)
+const DefaultMaxAttachmentSize int64 = 100 << 20
+
+// checksumManifest is the file written next to downloaded attachments, in sha256sum format
+const checksumManifest = "SHA256SUMS"
+
+// AttachmentsResult lists the attachments of an issue
+type AttachmentsResult struct {
+	IssueKey    string       `json:"issueKey" yaml:"issueKey"`
+	Attachments []Attachment `json:"attachments" yaml:"attachments"`
+}
+
+// FetchIssueAttachments retrieves the attachment metadata of an issue
+func FetchIssueAttachments(jiraURL, apikey, issueKey string) (*AttachmentsResult, error) {
+	if jiraURL == "" || apikey == "" || issueKey == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, and issueKey must be provided")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	jiraIssue, resp, err := client.Issue.Get(issueKey, &jira.GetQueryOptions{Fields: "attachment"})
+	if err != nil {
+		return nil, fmt.Errorf("fetching issue %s: %w", issueKey, err)
+	}
+	if resp != nil && resp.StatusCode != 200 {
+		return nil, fmt.Errorf("jira API error for %s: %s", issueKey, resp.Status)
+	}
+
+	issue := convertJiraIssue(*jiraIssue)
+	return &AttachmentsResult{
+		IssueKey:    issueKey,
+		Attachments: issue.Attachments,
+	}, nil
+}
+
+// DownloadAttachments downloads every attachment of the result into dir, checking each
+// file's size against the Jira metadata and recording its SHA-256 checksum. Attachments
+// larger than maxSize are rejected. When dir already holds a SHA256SUMS manifest, a file
+// listed in it must match the recorded checksum or the download fails and the existing
+// file is kept. The manifest is then updated with the new checksums.
+func DownloadAttachments(jiraURL, apikey string, result *AttachmentsResult, dir string, maxSize int64) error {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return fmt.Errorf("creating download directory: %w", err)
+	}
+	recorded, err := readChecksumManifest(dir)
+	if err != nil {
+		return err
+	}
+
+	httpClient := getHTTPClient(apikey)
+	used := map[string]bool{}
+	for i := range result.Attachments {
+		a := &result.Attachments[i]
+
+		name := attachmentFileName(*a, used)
+		used[name] = true
+
+		path := filepath.Join(dir, name)
+		checksum, err := downloadAttachment(httpClient, jiraURL, *a, path, maxSize, recorded[name])
+		if err != nil {
+			return fmt.Errorf("downloading %s: %w", a.Filename, err)
+		}
+		a.Path = path
+		a.SHA256 = checksum
+		recorded[name] = checksum
+	}
+
+	return writeChecksumManifest(dir, recorded)
+}
+
+// attachmentFileName returns the name an attachment is saved under in the download
+// directory. Path components are stripped; names that would escape or denote the
+// directory are replaced and names already used are prefixed with the attachment ID.
+func attachmentFileName(a Attachment, used map[string]bool) string {
+	name := filepath.Base(a.Filename)
+	switch name {
+	case "", ".", "..", string(filepath.Separator):
+		return "attachment-" + a.ID
+	}
+	if used[name] {
+		name = a.ID + "-" + name
+	}
+	return name
+}
+
+// downloadAttachment streams one attachment to path and returns its SHA-256 checksum.
+// The file is written to a temporary name and only renamed into place once its size
+// matches the metadata and its checksum matches expected, when one is given.
+func downloadAttachment(httpClient *http.Client, jiraURL string, a Attachment, path string, maxSize int64, expected string) (string, error) {
+	if a.Size > maxSize {
+		return "", fmt.Errorf("attachment size %d exceeds limit of %d bytes", a.Size, maxSize)
+	}
+	// Never send the API token to a host other than the configured Jira instance
+	if !strings.HasPrefix(a.Content, strings.TrimSuffix(jiraURL, "/")+"/") {
+		return "", fmt.Errorf("attachment URL %q is outside the Jira instance", a.Content)
+	}
+
+	req, err := http.NewRequest("GET", a.Content, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch attachment: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("jira API returned status %d: %s",
+			resp.StatusCode, string(bodyBytes))
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".download-*")
+	if err != nil {
+		return "", fmt.Errorf("creating temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	hash := sha256.New()
+	written, err := io.Copy(io.MultiWriter(tmp, hash), io.LimitReader(resp.Body, maxSize+1))
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return "", fmt.Errorf("writing attachment: %w", err)
+	}
+	if written > maxSize {
+		return "", fmt.Errorf("attachment exceeds limit of %d bytes", maxSize)
+	}
+	if written != a.Size {
+		return "", fmt.Errorf("size mismatch: expected %d bytes, received %d", a.Size, written)
+	}
+	checksum := hex.EncodeToString(hash.Sum(nil))
+	if expected != "" && !strings.EqualFold(checksum, expected) {
+		return "", fmt.Errorf("checksum mismatch: %s records %s, received %s", checksumManifest, expected, checksum)
+	}
+
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", fmt.Errorf("saving attachment: %w", err)
+	}
+	return checksum, nil
+}
+
+// readChecksumManifest reads the SHA256SUMS manifest of dir, keyed by file name. A missing
+// manifest yields no checksums.
+func readChecksumManifest(dir string) (map[string]string, error) {
+	checksums := map[string]string{}
+	f, err := os.Open(filepath.Join(dir, checksumManifest))
+	if errors.Is(err, os.ErrNotExist) {
+		return checksums, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading checksum manifest: %w", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		// sha256sum writes "<checksum>  <name>", with "*" instead of the second space in binary mode
+		checksum, name, ok := strings.Cut(scanner.Text(), " ")
+		if !ok {
+			continue
+		}
+		checksums[strings.TrimPrefix(strings.TrimPrefix(name, " "), "*")] = checksum
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading checksum manifest: %w", err)
+	}
+	return checksums, nil
+}
+
+// writeChecksumManifest records the checksums of the files in dir, keyed by file name, so
+// the archive can later be checked with "sha256sum -c SHA256SUMS"
+func writeChecksumManifest(dir string, checksums map[string]string) error {
+	var lines []string
+	for name, checksum := range checksums {
+		lines = append(lines, fmt.Sprintf("%s  %s\n", checksum, name))
+	}
+	sort.Strings(lines)
+
+	if err := os.WriteFile(filepath.Join(dir, checksumManifest), []byte(strings.Join(lines, "")), 0o644); err != nil {
+		return fmt.Errorf("writing checksum manifest: %w", err)
+	}
+	return nil
+}
+
+func printAttachmentsTable(w *tabwriter.Writer, result *AttachmentsResult) {
+	fmt.Fprintln(w, "FILENAME\tSIZE\tMIME TYPE\tAUTHOR\tCREATED\tPATH")
+	for _, a := range result.Attachments {
+		author := ""
+		if a.Author != nil {
+			author = a.Author.DisplayName
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\t%s\n", a.Filename, a.Size, a.MimeType, author, a.Created, a.Path)
+	}
+}
@@ -0,0 +1,176 @@
+package lib
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const attachmentBody = "test log contents\n"
+
+// attachmentServer serves an issue with two attachments and their contents
+func attachmentServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/rest/api/2/issue/TEST-123":
+			assert.Equal(t, "attachment", r.URL.Query().Get("fields"))
+			w.Header().Set("Content-Type", "application/json")
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"key": "TEST-123",
+				"fields": map[string]interface{}{
+					"attachment": []map[string]interface{}{
+						{
+							"id":       "1",
+							"filename": "build.log",
+							"size":     len(attachmentBody),
+							"mimeType": "text/plain",
+							"author":   map[string]string{"displayName": "Test User"},
+							"created":  "2025-01-01T12:00:00.000-0700",
+							"content":  server.URL + "/secure/attachment/1/build.log",
+						},
+						{
+							"id":       "2",
+							"filename": "../build.log",
+							"size":     len(attachmentBody),
+							"mimeType": "text/plain",
+							"created":  "2025-01-02T12:00:00.000-0700",
+							"content":  server.URL + "/secure/attachment/2/build.log",
+						},
+					},
+				},
+			})
+		case "/secure/attachment/1/build.log", "/secure/attachment/2/build.log":
+			assert.Equal(t, "Bearer test-token", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(attachmentBody))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func TestFetchIssueAttachments(t *testing.T) {
+	server := attachmentServer(t)
+	defer server.Close()
+
+	result, err := FetchIssueAttachments(server.URL, "test-token", "TEST-123")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST-123", result.IssueKey)
+	assert.Len(t, result.Attachments, 2)
+	assert.Equal(t, "build.log", result.Attachments[0].Filename)
+	assert.Equal(t, int64(len(attachmentBody)), result.Attachments[0].Size)
+	assert.Equal(t, "text/plain", result.Attachments[0].MimeType)
+	assert.Equal(t, "Test User", result.Attachments[0].Author.DisplayName)
+	assert.Equal(t, "2025-01-01T12:00:00-07:00", result.Attachments[0].Created)
+
+	assert.NoError(t, PrintTable(result))
+}
+
+func TestFetchIssueAttachments_EmptyConfig(t *testing.T) {
+	result, err := FetchIssueAttachments("", "", "TEST-123")
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
+
+func TestDownloadAttachments(t *testing.T) {
+	server := attachmentServer(t)
+	defer server.Close()
+
+	result, err := FetchIssueAttachments(server.URL, "test-token", "TEST-123")
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	err = DownloadAttachments(server.URL, "test-token", result, dir, DefaultMaxAttachmentSize)
+	assert.NoError(t, err)
+
+	// Duplicate names are disambiguated and path components are stripped
+	assert.Equal(t, filepath.Join(dir, "build.log"), result.Attachments[0].Path)
+	assert.Equal(t, filepath.Join(dir, "2-build.log"), result.Attachments[1].Path)
+
+	content, err := os.ReadFile(result.Attachments[0].Path)
+	assert.NoError(t, err)
+	assert.Equal(t, attachmentBody, string(content))
+	assert.Len(t, result.Attachments[0].SHA256, 64)
+
+	manifest, err := os.ReadFile(filepath.Join(dir, "SHA256SUMS"))
+	assert.NoError(t, err)
+	assert.Contains(t, string(manifest), result.Attachments[0].SHA256+"  build.log")
+}
+
+func TestDownloadAttachments_Limits(t *testing.T) {
+	server := attachmentServer(t)
+	defer server.Close()
+
+	t.Run("TooLarge", func(t *testing.T) {
+		result, err := FetchIssueAttachments(server.URL, "test-token", "TEST-123")
+		assert.NoError(t, err)
+		err = DownloadAttachments(server.URL, "test-token", result, t.TempDir(), 4)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "exceeds limit")
+	})
+
+	t.Run("SizeMismatch", func(t *testing.T) {
+		result, err := FetchIssueAttachments(server.URL, "test-token", "TEST-123")
+		assert.NoError(t, err)
+		result.Attachments[0].Size = 5
+		dir := t.TempDir()
+		err = DownloadAttachments(server.URL, "test-token", result, dir, DefaultMaxAttachmentSize)
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(dir, "build.log"))
+	})
+
+	t.Run("ForeignHost", func(t *testing.T) {
+		result := &AttachmentsResult{
+			IssueKey:    "TEST-123",
+			Attachments: []Attachment{{ID: "9", Filename: "x.log", Size: 1, Content: "https://elsewhere.example.com/x.log"}},
+		}
+		err := DownloadAttachments(server.URL, "test-token", result, t.TempDir(), DefaultMaxAttachmentSize)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "outside the Jira instance")
+	})
+}
+
+func TestDownloadAttachments_Verify(t *testing.T) {
+	server := attachmentServer(t)
+	defer server.Close()
+
+	result, err := FetchIssueAttachments(server.URL, "test-token", "TEST-123")
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	assert.NoError(t, DownloadAttachments(server.URL, "test-token", result, dir, DefaultMaxAttachmentSize))
+
+	// Downloading again into the same directory matches the recorded checksums
+	assert.NoError(t, DownloadAttachments(server.URL, "test-token", result, dir, DefaultMaxAttachmentSize))
+
+	// An archived file recorded with different contents no longer matches the download
+	archived := "test log contentX\n"
+	sum := sha256.Sum256([]byte(archived))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "build.log"), []byte(archived), 0o644))
+	manifest := hex.EncodeToString(sum[:]) + "  build.log\n"
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "SHA256SUMS"), []byte(manifest), 0o644))
+
+	err = DownloadAttachments(server.URL, "test-token", result, dir, DefaultMaxAttachmentSize)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "checksum mismatch")
+	content, err := os.ReadFile(filepath.Join(dir, "build.log"))
+	assert.NoError(t, err)
+	assert.Equal(t, archived, string(content))
+}
+
+func TestAttachmentFileName(t *testing.T) {
+	used := map[string]bool{"build.log": true}
+	assert.Equal(t, "notes.txt", attachmentFileName(Attachment{ID: "1", Filename: "notes.txt"}, used))
+	assert.Equal(t, "notes.txt", attachmentFileName(Attachment{ID: "1", Filename: "../../notes.txt"}, used))
+	assert.Equal(t, "2-build.log", attachmentFileName(Attachment{ID: "2", Filename: "build.log"}, used))
+	for _, filename := range []string{"..", "../..", "", "/", "."} {
+		assert.Equal(t, "attachment-3", attachmentFileName(Attachment{ID: "3", Filename: filename}, used), filename)
+	}
+}
@@ -0,0 +1,277 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"text/tabwriter"
+	"time"
+)
+
+// Burndown units
+const (
+	BurndownPoints = "points"
+	BurndownIssues = "issues"
+)
+
+// BurndownDay holds the ideal and actual work of one day. The actual values are taken at
+// the end of the day and are nil for days that have not ended.
+type BurndownDay struct {
+	Date      string   `json:"date" yaml:"date"`
+	Ideal     float64  `json:"ideal" yaml:"ideal"`
+	Remaining *float64 `json:"remaining,omitempty" yaml:"remaining,omitempty"`
+	Scope     *float64 `json:"scope,omitempty" yaml:"scope,omitempty"`
+	Completed *float64 `json:"completed,omitempty" yaml:"completed,omitempty"`
+}
+
+// BurndownReport holds the daily burndown of a sprint or version in story points or issues.
+// Remaining is the burndown; Scope and Completed make up the burnup.
+type BurndownReport struct {
+	Sprint  string        `json:"sprint,omitempty" yaml:"sprint,omitempty"`
+	Version string        `json:"version,omitempty" yaml:"version,omitempty"`
+	Unit    string        `json:"unit" yaml:"unit"`
+	Start   string        `json:"start" yaml:"start"`
+	End     string        `json:"end" yaml:"end"`
+	Days    []BurndownDay `json:"days" yaml:"days"`
+}
+
+// membershipAt replays the changes to a field to tell whether an issue belonged to a sprint
+// or version at the given time. contains tells whether a field value includes it; current is
+// the membership today. Changes that do not add or remove it are ignored.
+func membershipAt(changes []fieldChange, at time.Time, current bool, contains func(string) bool) bool {
+	member := current
+	for i := len(changes) - 1; i >= 0 && changes[i].At.After(at); i-- {
+		if c := changes[i]; contains(c.From) != contains(c.To) {
+			member = contains(c.From)
+		}
+	}
+	return member
+}
+
+// burndownIssue is an issue with a function telling whether it was in scope at a time
+type burndownIssue struct {
+	issue   Issue
+	inScope func(at time.Time) bool
+}
+
+// newBurndownIssue replays the changes to field to tell whether the issue was in scope. Jira
+// records no change when the sprint or fix version is set at creation, so the issue is never
+// in scope before it was created.
+func newBurndownIssue(issue Issue, field string, current bool, contains func(string) bool) burndownIssue {
+	changes := fieldChanges(issue, field)
+	created, err := parseJiraTime(issue.Created)
+	return burndownIssue{issue, func(at time.Time) bool {
+		if err == nil && at.Before(created) {
+			return false
+		}
+		return membershipAt(changes, at, current, contains)
+	}}
+}
+
+// buildBurndown computes the daily burndown of the issues from start until the last day of
+// end, in the configured timezone. Actual values are taken no later than limit.
+func buildBurndown(issues []burndownIssue, start, end, limit time.Time, unit string, categories StatusCategories) (*BurndownReport, error) {
+	var value func(issue Issue, at time.Time) float64
+	switch unit {
+	case BurndownPoints:
+		value = pointsAt
+	case BurndownIssues:
+		value = func(Issue, time.Time) float64 { return 1 }
+	default:
+		return nil, fmt.Errorf("unknown unit %q, expected points or issues", unit)
+	}
+	if !end.After(start) {
+		return nil, fmt.Errorf("the end %s is not after the start %s", end.Format(time.RFC3339), start.Format(time.RFC3339))
+	}
+
+	// measure returns the scope and completed work at a time
+	measure := func(at time.Time) (scope, completed float64) {
+		for _, bi := range issues {
+			if !bi.inScope(at) {
+				continue
+			}
+			v := value(bi.issue, at)
+			scope += v
+			status := fieldValueAt(fieldChanges(bi.issue, "status"), at, bi.issue.Status.Name)
+			if categories.Category(status) == CategoryDone {
+				completed += v
+			}
+		}
+		return scope, completed
+	}
+
+	loc := GetTimezone()
+	first, last := startOfDay(start.In(loc)), startOfDay(end.In(loc))
+	report := &BurndownReport{Unit: unit, Start: first.Format("2006-01-02"), End: last.Format("2006-01-02")}
+
+	scope, completed := measure(start)
+	committed := scope - completed
+	days := 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		days++
+	}
+
+	i := 0
+	for day := first; !day.After(last); day = day.AddDate(0, 0, 1) {
+		bd := BurndownDay{Date: day.Format("2006-01-02")}
+		if days > 1 {
+			bd.Ideal = committed * (1 - float64(i)/float64(days-1))
+		}
+		if at := day.AddDate(0, 0, 1).Add(-time.Nanosecond); !day.After(limit) {
+			if at.After(limit) {
+				at = limit
+			}
+			scope, completed := measure(at)
+			remaining := scope - completed
+			bd.Remaining, bd.Scope, bd.Completed = &remaining, &scope, &completed
+		}
+		report.Days = append(report.Days, bd)
+		i++
+	}
+	return report, nil
+}
+
+// BuildSprintBurndown replays the Sprint, status and story point changes of the issues to
+// compute the daily burndown of a sprint from its start to its planned end. inSprint holds
+// the keys of issues currently in the sprint, as for BuildSprintReport. Completed work is
+// work in a status of the done category.
+func BuildSprintBurndown(sprint Sprint, issues []Issue, inSprint map[string]bool, unit string, categories StatusCategories, now time.Time) (*BurndownReport, error) {
+	if sprint.StartDate == nil {
+		return nil, fmt.Errorf("sprint %d has not started", sprint.ID)
+	}
+	end := sprintReportEnd(sprint, now)
+	if sprint.EndDate != nil {
+		end = *sprint.EndDate
+	}
+	limit := now
+	if sprint.CompleteDate != nil && sprint.CompleteDate.Before(limit) {
+		limit = *sprint.CompleteDate
+	}
+
+	var scoped []burndownIssue
+	for _, issue := range issues {
+		scoped = append(scoped, newBurndownIssue(issue, "Sprint", inSprint[issue.Key], func(v string) bool {
+			return sprintNamesContain(v, sprint.Name)
+		}))
+	}
+
+	report, err := buildBurndown(scoped, *sprint.StartDate, end, limit, unit, categories)
+	if err != nil {
+		return nil, err
+	}
+	report.Sprint = sprint.Name
+	return report, nil
+}
+
+// BuildVersionBurndown replays the Fix Version, status and story point changes of the issues
+// to compute the daily burndown of a version over the date range. Completed work is work in
+// a status of the done category.
+func BuildVersionBurndown(version string, issues []Issue, dateRange DateRange, unit string, categories StatusCategories, now time.Time) (*BurndownReport, error) {
+	var scoped []burndownIssue
+	for _, issue := range issues {
+		current := false
+		for _, v := range issue.FixVersions {
+			current = current || v.Name == version
+		}
+		scoped = append(scoped, newBurndownIssue(issue, "Fix Version", current, func(v string) bool { return v == version }))
+	}
+
+	report, err := buildBurndown(scoped, dateRange.Start, dateRange.End.Add(-time.Nanosecond), now, unit, categories)
+	if err != nil {
+		return nil, err
+	}
+	report.Version = version
+	return report, nil
+}
+
+// FetchSprintBurndown fetches a sprint and its issues and builds its burndown. scopeJQL
+// optionally adds candidate issues that may have left the sprint. See BuildSprintBurndown.
+func FetchSprintBurndown(jiraURL, apikey string, sprintID int, scopeJQL, unit string, categories StatusCategories, verbose bool) (*BurndownReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+
+	sprint, err := FetchSprint(jiraURL, apikey, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sprint %d: %w", sprintID, err)
+	}
+	issues, inSprint, err := fetchScopedIssues(jiraURL, apikey, fmt.Sprintf("sprint = %d", sprintID), scopeJQL, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return BuildSprintBurndown(*sprint, issues, inSprint, unit, categories, time.Now())
+}
+
+// FetchVersionBurndown fetches the issues of a project version and builds its burndown from
+// startDate to endDate. An empty startDate or endDate defaults to the start or release date
+// of the version, and to today when it has no release date. scopeJQL optionally adds
+// candidate issues that may have left the version. See BuildVersionBurndown.
+func FetchVersionBurndown(jiraURL, apikey, projectKey, version, startDate, endDate, scopeJQL, unit string, categories StatusCategories, verbose bool) (*BurndownReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if projectKey == "" || version == "" {
+		return nil, fmt.Errorf("project and version must be provided")
+	}
+
+	if startDate == "" || endDate == "" {
+		versions, err := FetchProjectVersions(jiraURL, apikey, projectKey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching versions of %s: %w", projectKey, err)
+		}
+		var found *ProjectVersion
+		for i := range versions.Versions {
+			if versions.Versions[i].Name == version {
+				found = &versions.Versions[i]
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("version %q not found in project %s", version, projectKey)
+		}
+		if startDate == "" {
+			if found.StartDate == "" {
+				return nil, fmt.Errorf("version %q has no start date; give a start date", version)
+			}
+			startDate = found.StartDate
+		}
+		if endDate == "" {
+			endDate = found.ReleaseDate
+			if endDate == "" {
+				endDate = "today"
+			}
+		}
+	}
+
+	now := time.Now()
+	dateRange, err := ParseDateRange(startDate, endDate, now)
+	if err != nil {
+		return nil, err
+	}
+
+	jql := fmt.Sprintf(`project = "%s" AND fixVersion = "%s"`, projectKey, version)
+	issues, _, err := fetchScopedIssues(jiraURL, apikey, jql, scopeJQL, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return BuildVersionBurndown(version, issues, dateRange, unit, categories, now)
+}
+
+// formatBurndownValue formats an actual value, or "-" for days that have not ended
+func formatBurndownValue(v *float64) string {
+	if v == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%g", *v)
+}
+
+func printBurndownTable(w *tabwriter.Writer, report *BurndownReport) {
+	name := report.Sprint
+	if name == "" {
+		name = report.Version
+	}
+	fmt.Fprintf(w, "Burndown of %s in %s\n\n", name, report.Unit)
+	fmt.Fprintln(w, "DATE\tIDEAL\tREMAINING\tSCOPE\tCOMPLETED")
+	for _, day := range report.Days {
+		fmt.Fprintf(w, "%s\t%g\t%s\t%s\t%s\n", day.Date, math.Round(day.Ideal*10)/10,
+			formatBurndownValue(day.Remaining), formatBurndownValue(day.Scope), formatBurndownValue(day.Completed))
+	}
+}
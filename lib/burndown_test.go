@@ -0,0 +1,146 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMembershipAt(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	contains := func(v string) bool { return v == "4.16" }
+	changes := []fieldChange{
+		{At: day(3), From: "", To: "4.16"},
+		// Adding another version leaves the membership unchanged
+		{At: day(4), From: "", To: "4.17"},
+		{At: day(6), From: "4.16", To: ""},
+	}
+
+	assert.False(t, membershipAt(changes, day(2), false, contains))
+	assert.True(t, membershipAt(changes, day(5), false, contains))
+	assert.False(t, membershipAt(changes, day(7), false, contains))
+	assert.True(t, membershipAt(nil, day(1), true, contains))
+}
+
+func TestBuildSprintBurndown(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	at := func(d, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+	change := func(when time.Time, field, from, to string) HistoryItem {
+		return HistoryItem{Created: when, Items: []HistoryChange{{Field: field, FromString: from, ToString: to}}}
+	}
+	start, end := at(1, 9), at(5, 17)
+	sprint := Sprint{ID: 1, Name: "Sprint 1", StartDate: &start, EndDate: &end}
+
+	issues := []Issue{
+		{
+			Key: "TEST-1", Created: "2023-12-20T00:00:00Z", Status: Status{Name: "Done"}, StoryPoints: 3,
+			History: []HistoryItem{change(at(2, 12), "status", "New", "Done")},
+		},
+		{
+			// Re-estimated from 5 to 8 points on the third day
+			Key: "TEST-2", Created: "2023-12-20T00:00:00Z", Status: Status{Name: "New"}, StoryPoints: 8,
+			History: []HistoryItem{change(at(3, 12), "Story Points", "5", "8")},
+		},
+		{
+			// Added to the sprint on the second day
+			Key: "TEST-3", Created: "2024-01-02T00:00:00Z", Status: Status{Name: "New"}, StoryPoints: 2,
+			History: []HistoryItem{change(at(2, 10), "Sprint", "", "Sprint 1")},
+		},
+	}
+	inSprint := map[string]bool{"TEST-1": true, "TEST-2": true, "TEST-3": true}
+
+	report, err := BuildSprintBurndown(sprint, issues, inSprint, BurndownPoints, StatusCategories{}, at(4, 12))
+	assert.NoError(t, err)
+	assert.Equal(t, "Sprint 1", report.Sprint)
+	assert.Len(t, report.Days, 5)
+
+	// Eight points committed at the start burn down ideally by two a day
+	assert.Equal(t, 8.0, report.Days[0].Ideal)
+	assert.Equal(t, 2.0, report.Days[3].Ideal)
+	assert.Equal(t, 0.0, report.Days[4].Ideal)
+
+	assert.Equal(t, 8.0, *report.Days[0].Remaining)
+	assert.Equal(t, 7.0, *report.Days[1].Remaining)
+	assert.Equal(t, 10.0, *report.Days[1].Scope)
+	assert.Equal(t, 3.0, *report.Days[1].Completed)
+	assert.Equal(t, 10.0, *report.Days[2].Remaining)
+	// Today is partly over and tomorrow has not started
+	assert.NotNil(t, report.Days[3].Remaining)
+	assert.Nil(t, report.Days[4].Remaining)
+
+	counted, err := BuildSprintBurndown(sprint, issues, inSprint, BurndownIssues, StatusCategories{}, at(4, 12))
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, *counted.Days[2].Remaining)
+
+	_, err = BuildSprintBurndown(sprint, issues, inSprint, "hours", StatusCategories{}, at(4, 12))
+	assert.Error(t, err)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintCSV(report))
+	assert.NoError(t, PrintSVG(report))
+}
+
+func TestBuildVersionBurndown(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	issues := []Issue{
+		{
+			Key: "TEST-1", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "New"},
+			FixVersions: []Version{{Name: "4.16"}},
+		},
+		{
+			// Moved to the next version on the second day
+			Key: "TEST-2", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "New"},
+			FixVersions: []Version{{Name: "4.17"}},
+			History: []HistoryItem{{
+				Created: time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+				Items: []HistoryChange{
+					{Field: "Fix Version", FromString: "4.16"},
+					{Field: "Fix Version", ToString: "4.17"},
+				},
+			}},
+		},
+	}
+	dateRange, err := ParseDateRange("2024-01-01", "2024-01-03", time.Now())
+	assert.NoError(t, err)
+
+	report, err := BuildVersionBurndown("4.16", issues, dateRange, BurndownIssues, StatusCategories{}, time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC))
+	assert.NoError(t, err)
+	assert.Equal(t, "4.16", report.Version)
+	assert.Len(t, report.Days, 3)
+	assert.Equal(t, 2.0, *report.Days[0].Remaining)
+	assert.Equal(t, 1.0, *report.Days[1].Remaining)
+}
+
+func TestBurndown_CreatedMidRange(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	start, end := time.Date(2024, 1, 1, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 3, 17, 0, 0, 0, time.UTC)
+	sprint := Sprint{ID: 1, Name: "Sprint 1", StartDate: &start, EndDate: &end}
+	// TEST-2 was created on the second day with the sprint and fix version already set, so
+	// there is no Sprint or Fix Version change in its history
+	issues := []Issue{
+		{Key: "TEST-1", Created: "2023-12-20T00:00:00Z", Status: Status{Name: "New"}, FixVersions: []Version{{Name: "4.16"}}},
+		{Key: "TEST-2", Created: "2024-01-02T12:00:00Z", Status: Status{Name: "New"}, FixVersions: []Version{{Name: "4.16"}}},
+	}
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	report, err := BuildSprintBurndown(sprint, issues, map[string]bool{"TEST-1": true, "TEST-2": true}, BurndownIssues, StatusCategories{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, report.Days[0].Ideal)
+	assert.Equal(t, 1.0, *report.Days[0].Scope)
+	assert.Equal(t, 2.0, *report.Days[1].Scope)
+
+	dateRange, err := ParseDateRange("2024-01-01", "2024-01-03", now)
+	assert.NoError(t, err)
+	version, err := BuildVersionBurndown("4.16", issues, dateRange, BurndownIssues, StatusCategories{}, now)
+	assert.NoError(t, err)
+	assert.Equal(t, 1.0, version.Days[0].Ideal)
+	assert.Equal(t, 1.0, *version.Days[0].Scope)
+	assert.Equal(t, 2.0, *version.Days[1].Scope)
+}
@@ -0,0 +1,84 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// cacheDir is the directory holding cached Jira metadata. Empty means the user cache directory.
+var cacheDir string
+
+// SetCacheDir overrides the directory used to cache Jira metadata
+func SetCacheDir(dir string) {
+	cacheDir = dir
+}
+
+// GetCacheDir returns the directory used to cache Jira metadata
+func GetCacheDir() string {
+	if cacheDir != "" {
+		return cacheDir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		dir = os.TempDir()
+	}
+	return filepath.Join(dir, "jiracrawler")
+}
+
+// cachePath returns the cache file of an entry for a Jira instance
+func cachePath(jiraURL, name string) string {
+	host := jiraURL
+	if u, err := url.Parse(jiraURL); err == nil && u.Host != "" {
+		host = u.Host
+	}
+	return filepath.Join(GetCacheDir(), cacheFileName(host), cacheFileName(name)+".json")
+}
+
+// cacheFileName replaces the characters of a cache name that are not letters, digits, dots,
+// dashes or underscores, so names built from user input stay inside the cache directory
+func cacheFileName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '.' || r == '-' || r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// SaveCache stores v as JSON in the cache of a Jira instance
+func SaveCache(jiraURL, name string, v interface{}) error {
+	path := cachePath(jiraURL, name)
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("creating cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encoding cache entry %s: %w", name, err)
+	}
+	return os.WriteFile(path, data, 0o600)
+}
+
+// LoadCache decodes a cached entry into v. It reports false if the entry is missing,
+// unreadable, or older than maxAge. A maxAge of zero accepts entries of any age.
+func LoadCache(jiraURL, name string, maxAge time.Duration, v interface{}) bool {
+	path := cachePath(jiraURL, name)
+	info, err := os.Stat(path)
+	if err != nil {
+		return false
+	}
+	if maxAge > 0 && time.Since(info.ModTime()) > maxAge {
+		return false
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(data, v) == nil
+}
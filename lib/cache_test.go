@@ -0,0 +1,51 @@
+package lib
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCache_SaveLoad(t *testing.T) {
+	SetCacheDir(t.TempDir())
+	defer SetCacheDir("")
+
+	saved := &ProjectListResult{Projects: []Project{{Key: "CNF", Name: "Cloud Native Functions"}}}
+	assert.NoError(t, SaveCache("https://issues.example.com", ProjectsCacheName, saved))
+
+	var loaded ProjectListResult
+	assert.True(t, LoadCache("https://issues.example.com", ProjectsCacheName, time.Hour, &loaded))
+	assert.Equal(t, saved.Projects, loaded.Projects)
+
+	// Entries are kept per Jira instance
+	assert.False(t, LoadCache("https://other.example.com", ProjectsCacheName, time.Hour, &loaded))
+}
+
+func TestCache_Expired(t *testing.T) {
+	SetCacheDir(t.TempDir())
+	defer SetCacheDir("")
+
+	assert.NoError(t, SaveCache("https://issues.example.com", "entry", []string{"a"}))
+	old := time.Now().Add(-2 * time.Hour)
+	assert.NoError(t, os.Chtimes(cachePath("https://issues.example.com", "entry"), old, old))
+
+	var loaded []string
+	assert.False(t, LoadCache("https://issues.example.com", "entry", time.Hour, &loaded))
+	assert.True(t, LoadCache("https://issues.example.com", "entry", 0, &loaded))
+}
+
+func TestCache_NameStaysInCacheDir(t *testing.T) {
+	dir := t.TempDir()
+	SetCacheDir(dir)
+	defer SetCacheDir("")
+
+	path := cachePath("https://issues.example.com:8443", "users-../../evil")
+	assert.Equal(t, filepath.Join(dir, "issues.example.com_8443", "users-.._.._evil.json"), path)
+
+	assert.NoError(t, SaveCache("https://issues.example.com", "../escape", []string{"a"}))
+	_, err := os.Stat(filepath.Join(dir, "escape.json"))
+	assert.True(t, os.IsNotExist(err))
+}
@@ -0,0 +1,136 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// CFDDay counts the issues in each status at the end of one day
+type CFDDay struct {
+	Date   string         `json:"date" yaml:"date"`
+	Counts map[string]int `json:"counts" yaml:"counts"`
+}
+
+// CFDReport holds the data of a cumulative flow diagram: the number of issues in each status
+// at the end of every day of the range. Statuses are ordered from to do through done.
+type CFDReport struct {
+	JQL       string   `json:"jql" yaml:"jql"`
+	DateRange string   `json:"dateRange" yaml:"dateRange"`
+	Statuses  []string `json:"statuses" yaml:"statuses"`
+	Days      []CFDDay `json:"days" yaml:"days"`
+}
+
+// categoryOrder ranks the status categories in the order work flows through them
+var categoryOrder = map[string]int{
+	CategoryToDo:       0,
+	CategoryInProgress: 1,
+	CategoryDone:       2,
+}
+
+// BuildCFD replays the status history of the issues to count, at the end of each day of the
+// range in the configured timezone, how many issues that existed by then were in each status.
+// Days are snapshotted no later than now so the last day reflects the current state.
+func BuildCFD(issues []Issue, dateRange DateRange, categories StatusCategories, now time.Time) *CFDReport {
+	report := &CFDReport{DateRange: dateRange.String()}
+	loc := GetTimezone()
+
+	type replay struct {
+		created time.Time
+		changes []fieldChange
+		current string
+	}
+	var replays []replay
+	for _, issue := range issues {
+		created, err := parseJiraTime(issue.Created)
+		if err != nil {
+			continue
+		}
+		replays = append(replays, replay{created, fieldChanges(issue, "status"), issue.Status.Name})
+	}
+
+	firstSeen := make(map[string]int)
+	for day := startOfDay(dateRange.Start.In(loc)); day.Before(dateRange.End) && !day.After(now); day = day.AddDate(0, 0, 1) {
+		snapshot := day.AddDate(0, 0, 1).Add(-time.Nanosecond)
+		if snapshot.After(now) {
+			snapshot = now
+		}
+
+		counts := make(map[string]int)
+		for _, r := range replays {
+			if r.created.After(snapshot) {
+				continue
+			}
+			status := fieldValueAt(r.changes, snapshot, r.current)
+			if _, ok := firstSeen[status]; !ok {
+				firstSeen[status] = len(firstSeen)
+			}
+			counts[status]++
+		}
+		report.Days = append(report.Days, CFDDay{Date: day.Format("2006-01-02"), Counts: counts})
+	}
+
+	for status := range firstSeen {
+		report.Statuses = append(report.Statuses, status)
+	}
+	sort.Slice(report.Statuses, func(i, j int) bool {
+		a, b := report.Statuses[i], report.Statuses[j]
+		if ca, cb := categoryOrder[categories.Category(a)], categoryOrder[categories.Category(b)]; ca != cb {
+			return ca < cb
+		}
+		return firstSeen[a] < firstSeen[b]
+	})
+	return report
+}
+
+// FetchCFDReport fetches the issues matching the JQL query with their history and builds the
+// cumulative flow data for the days between startDate and endDate. See BuildCFD.
+func FetchCFDReport(jiraURL, apikey, jql, startDate, endDate string, categories StatusCategories, verbose bool) (*CFDReport, error) {
+	now := time.Now()
+	dateRange, err := ParseDateRange(startDate, endDate, now)
+	if err != nil {
+		return nil, err
+	}
+	issues, err := FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, err
+	}
+	report := BuildCFD(issues, dateRange, categories, now)
+	report.JQL = jql
+	return report, nil
+}
+
+func printCFDTable(w *tabwriter.Writer, report *CFDReport) {
+	header := []string{"DATE"}
+	for _, status := range report.Statuses {
+		header = append(header, strings.ToUpper(status))
+	}
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+	for _, day := range report.Days {
+		row := []string{day.Date}
+		for _, status := range report.Statuses {
+			row = append(row, fmt.Sprintf("%d", day.Counts[status]))
+		}
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+}
+
+func writeCFDMarkdown(b *strings.Builder, report *CFDReport) {
+	fmt.Fprintf(b, "# Cumulative flow (%s)\n\n", report.DateRange)
+	b.WriteString("| Date |")
+	for _, status := range report.Statuses {
+		fmt.Fprintf(b, " %s |", escapeMarkdown(status))
+	}
+	b.WriteString("\n|------|")
+	b.WriteString(strings.Repeat("---|", len(report.Statuses)))
+	b.WriteString("\n")
+	for _, day := range report.Days {
+		fmt.Fprintf(b, "| %s |", day.Date)
+		for _, status := range report.Statuses {
+			fmt.Fprintf(b, " %d |", day.Counts[status])
+		}
+		b.WriteString("\n")
+	}
+}
@@ -0,0 +1,46 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildCFD(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	day := func(d, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+	status := func(at time.Time, from, to string) HistoryItem {
+		return HistoryItem{Created: at, Items: []HistoryChange{{Field: "status", FromString: from, ToString: to}}}
+	}
+	issues := []Issue{
+		{
+			Key: "TEST-1", Created: "2024-01-01T09:00:00Z", Status: Status{Name: "Done"},
+			History: []HistoryItem{
+				status(day(2, 10), "New", "In Progress"),
+				status(day(3, 10), "In Progress", "Done"),
+			},
+		},
+		// Created on the second day and never moved
+		{Key: "TEST-2", Created: "2024-01-02T09:00:00Z", Status: Status{Name: "New"}},
+	}
+	categories := StatusCategories{"in progress": CategoryInProgress}
+
+	dateRange, err := ParseDateRange("2024-01-01", "2024-01-05", day(1, 0))
+	assert.NoError(t, err)
+	// Days after now are left out
+	report := BuildCFD(issues, dateRange, categories, day(4, 12))
+
+	assert.Equal(t, []string{"New", "In Progress", "Done"}, report.Statuses)
+	assert.Len(t, report.Days, 4)
+	assert.Equal(t, map[string]int{"New": 1}, report.Days[0].Counts)
+	assert.Equal(t, map[string]int{"New": 1, "In Progress": 1}, report.Days[1].Counts)
+	assert.Equal(t, map[string]int{"New": 1, "Done": 1}, report.Days[3].Counts)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+	assert.NoError(t, PrintSVG(report))
+}
@@ -0,0 +1,125 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// jiraTimeLayouts lists the timestamp formats returned by the Jira REST and Agile APIs
+var jiraTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339Nano,
+	time.RFC3339,
+}
+
+// parseJiraTime parses a timestamp in any of the formats returned by Jira
+func parseJiraTime(value string) (time.Time, error) {
+	for _, layout := range jiraTimeLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("unrecognized Jira timestamp %q", value)
+}
+
+// doneStatuses lists the status names treated as finished work
+var doneStatuses = []string{"Done", "Closed", "Resolved", "Verified"}
+
+// isDoneStatus reports whether the status name represents finished work
+func isDoneStatus(status string) bool {
+	for _, done := range doneStatuses {
+		if strings.EqualFold(done, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldChange is a single change to one field, flattened out of the issue history
+type fieldChange struct {
+	At     time.Time
+	Author string
+	From   string
+	To     string
+}
+
+// fieldChanges returns every change to the named field in chronological order.
+// Field names are matched case-insensitively since Jira uses "status" but "Sprint".
+func fieldChanges(issue Issue, field string) []fieldChange {
+	var changes []fieldChange
+	for _, h := range issue.History {
+		for _, item := range h.Items {
+			if !strings.EqualFold(item.Field, field) {
+				continue
+			}
+			changes = append(changes, fieldChange{
+				At:     h.Created,
+				Author: h.Author,
+				From:   item.FromString,
+				To:     item.ToString,
+			})
+		}
+	}
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].At.Before(changes[j].At)
+	})
+	return changes
+}
+
+// fieldValueAt replays the changes to reconstruct the value a field held at the given time.
+// current is the value the field holds today and is only used when the field never changed.
+func fieldValueAt(changes []fieldChange, at time.Time, current string) string {
+	if len(changes) == 0 {
+		return current
+	}
+	value := changes[0].From
+	for _, c := range changes {
+		if c.At.After(at) {
+			break
+		}
+		value = c.To
+	}
+	return value
+}
+
+// FetchIssuesWithHistory runs a JQL query, following pagination, and populates the
+// change history of every matching issue so it can be replayed by the report builders.
+func FetchIssuesWithHistory(jiraURL, apikey, jql string, verbose bool) ([]Issue, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if jql == "" {
+		return nil, fmt.Errorf("JQL query must not be empty")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	issues, err := searchAllIssues(client, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := GetGlobalRateLimiter()
+	for i := range issues {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching history for %s (%d/%d)...\n", issues[i].Key, i+1, len(issues))
+		}
+		history, err := FetchIssueHistory(client, jiraURL, issues[i].Key, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching history for %s: %w", issues[i].Key, err)
+		}
+		issues[i].History = history
+
+		if i < len(issues)-1 {
+			rl.Wait()
+		}
+	}
+
+	return issues, nil
+}
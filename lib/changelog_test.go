@@ -0,0 +1,58 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJiraTime(t *testing.T) {
+	for _, value := range []string{
+		"2025-01-01T12:00:00.000-0700",
+		"2025-01-01T19:00:00.000Z",
+		"2025-01-01T19:00:00Z",
+	} {
+		parsed, err := parseJiraTime(value)
+		assert.NoError(t, err, value)
+		assert.True(t, parsed.Equal(time.Date(2025, 1, 1, 19, 0, 0, 0, time.UTC)), value)
+	}
+
+	_, err := parseJiraTime("not-a-time")
+	assert.Error(t, err)
+}
+
+func TestFieldValueAt(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC) }
+	issue := Issue{
+		Status: Status{Name: "Done"},
+		History: []HistoryItem{
+			{Created: day(5), Items: []HistoryChange{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+			{Created: day(2), Items: []HistoryChange{{Field: "status", FromString: "Open", ToString: "In Progress"}}},
+		},
+	}
+	changes := fieldChanges(issue, "Status")
+	assert.Len(t, changes, 2)
+	assert.Equal(t, "Open", fieldValueAt(changes, day(1), issue.Status.Name))
+	assert.Equal(t, "In Progress", fieldValueAt(changes, day(2), issue.Status.Name))
+	assert.Equal(t, "In Progress", fieldValueAt(changes, day(4), issue.Status.Name))
+	assert.Equal(t, "Done", fieldValueAt(changes, day(6), issue.Status.Name))
+
+	assert.Equal(t, "Open", fieldValueAt(nil, day(1), "Open"))
+}
+
+func TestIsDoneStatus(t *testing.T) {
+	assert.True(t, isDoneStatus("Done"))
+	assert.True(t, isDoneStatus("closed"))
+	assert.False(t, isDoneStatus("In Progress"))
+}
+
+func TestFetchIssuesWithHistory_EmptyConfig(t *testing.T) {
+	issues, err := FetchIssuesWithHistory("", "", "project = CNF", false)
+	assert.Error(t, err)
+	assert.Nil(t, issues)
+
+	issues, err = FetchIssuesWithHistory("https://example.com", "token", "", false)
+	assert.Error(t, err)
+	assert.Nil(t, issues)
+}
@@ -0,0 +1,226 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+)
+
+// Churn flags
+const (
+	ChurnFlagBackward      = "backward"
+	ChurnFlagReopened      = "reopened"
+	ChurnFlagReassigned    = "reassigned"
+	ChurnFlagReprioritized = "reprioritized"
+)
+
+// DefaultChurnMinChanges is the number of assignee or priority changes at which an issue is flagged
+const DefaultChurnMinChanges = 3
+
+// ChurnIssue counts the changes of an issue that point to rework or unsettled ownership.
+// BackwardMoves includes the reopens and AssigneeChanges leaves out the first assignment;
+// Score sums the backward moves, assignee changes and priority changes, so a reopen counts
+// once.
+type ChurnIssue struct {
+	Key             string   `json:"key" yaml:"key"`
+	Summary         string   `json:"summary" yaml:"summary"`
+	Status          string   `json:"status" yaml:"status"`
+	Assignee        string   `json:"assignee" yaml:"assignee"`
+	BackwardMoves   int      `json:"backwardMoves" yaml:"backwardMoves"`
+	Reopens         int      `json:"reopens" yaml:"reopens"`
+	AssigneeChanges int      `json:"assigneeChanges" yaml:"assigneeChanges"`
+	PriorityChanges int      `json:"priorityChanges" yaml:"priorityChanges"`
+	Score           int      `json:"score" yaml:"score"`
+	Flags           []string `json:"flags" yaml:"flags"`
+}
+
+// ChurnTransition counts the moves between two statuses
+type ChurnTransition struct {
+	From  string `json:"from" yaml:"from"`
+	To    string `json:"to" yaml:"to"`
+	Count int    `json:"count" yaml:"count"`
+}
+
+// ChurnTotals sums the churn of every issue checked
+type ChurnTotals struct {
+	Issues          int `json:"issues" yaml:"issues"`
+	Flagged         int `json:"flagged" yaml:"flagged"`
+	BackwardMoves   int `json:"backwardMoves" yaml:"backwardMoves"`
+	Reopens         int `json:"reopens" yaml:"reopens"`
+	AssigneeChanges int `json:"assigneeChanges" yaml:"assigneeChanges"`
+	PriorityChanges int `json:"priorityChanges" yaml:"priorityChanges"`
+}
+
+// ChurnReport lists the issues with churn, worst first, and the backward transitions of the
+// workflow, most frequent first
+type ChurnReport struct {
+	JQL                 string            `json:"jql" yaml:"jql"`
+	Workflow            []string          `json:"workflow,omitempty" yaml:"workflow,omitempty"`
+	MinChanges          int               `json:"minChanges" yaml:"minChanges"`
+	Totals              ChurnTotals       `json:"totals" yaml:"totals"`
+	BackwardTransitions []ChurnTransition `json:"backwardTransitions" yaml:"backwardTransitions"`
+	Issues              []ChurnIssue      `json:"issues" yaml:"issues"`
+}
+
+// isBackwardMove reports whether a status change moves work back in the workflow. Statuses in
+// the workflow are compared by their position in it, others by their status category.
+func isBackwardMove(from, to string, workflow []string, categories StatusCategories) bool {
+	fromIndex, toIndex := -1, -1
+	for i, status := range workflow {
+		if strings.EqualFold(status, from) {
+			fromIndex = i
+		}
+		if strings.EqualFold(status, to) {
+			toIndex = i
+		}
+	}
+	if fromIndex >= 0 && toIndex >= 0 {
+		return toIndex < fromIndex
+	}
+	return categoryOrder[categories.Category(to)] < categoryOrder[categories.Category(from)]
+}
+
+// BuildChurnReport replays the status, assignee and priority changes of the issues. A status
+// change is backward when it moves to an earlier status of the workflow, an ordered list of
+// statuses, or to an earlier status category, and a reopen when it leaves the done category.
+// Issues are flagged for backward moves, reopens, and at least minChanges assignee or
+// priority changes. Only issues with churn are listed, worst first, and no more than top
+// of them when top is positive; the totals cover every issue.
+func BuildChurnReport(issues []Issue, workflow []string, categories StatusCategories, minChanges, top int) *ChurnReport {
+	report := &ChurnReport{Workflow: workflow, MinChanges: minChanges, BackwardTransitions: []ChurnTransition{}, Issues: []ChurnIssue{}}
+	transitions := map[[2]string]int{}
+
+	for _, issue := range issues {
+		report.Totals.Issues++
+		ci := ChurnIssue{
+			Key:             issue.Key,
+			Summary:         issue.Summary,
+			Status:          issue.Status.Name,
+			Assignee:        userDisplayName(issue.Assignee),
+			PriorityChanges: len(fieldChanges(issue, "priority")),
+			Flags:           []string{},
+		}
+		for _, c := range fieldChanges(issue, "assignee") {
+			if c.From != "" {
+				ci.AssigneeChanges++
+			}
+		}
+		for _, c := range fieldChanges(issue, "status") {
+			if !isBackwardMove(c.From, c.To, workflow, categories) {
+				continue
+			}
+			ci.BackwardMoves++
+			transitions[[2]string{c.From, c.To}]++
+			if categories.Category(c.From) == CategoryDone {
+				ci.Reopens++
+			}
+		}
+		ci.Score = ci.BackwardMoves + ci.AssigneeChanges + ci.PriorityChanges
+
+		report.Totals.BackwardMoves += ci.BackwardMoves
+		report.Totals.Reopens += ci.Reopens
+		report.Totals.AssigneeChanges += ci.AssigneeChanges
+		report.Totals.PriorityChanges += ci.PriorityChanges
+
+		if ci.BackwardMoves > ci.Reopens {
+			ci.Flags = append(ci.Flags, ChurnFlagBackward)
+		}
+		if ci.Reopens > 0 {
+			ci.Flags = append(ci.Flags, ChurnFlagReopened)
+		}
+		if minChanges > 0 && ci.AssigneeChanges >= minChanges {
+			ci.Flags = append(ci.Flags, ChurnFlagReassigned)
+		}
+		if minChanges > 0 && ci.PriorityChanges >= minChanges {
+			ci.Flags = append(ci.Flags, ChurnFlagReprioritized)
+		}
+		if len(ci.Flags) > 0 {
+			report.Totals.Flagged++
+		}
+		if ci.Score > 0 {
+			report.Issues = append(report.Issues, ci)
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool { return report.Issues[i].Score > report.Issues[j].Score })
+	if top > 0 && len(report.Issues) > top {
+		report.Issues = report.Issues[:top]
+	}
+	for t, count := range transitions {
+		report.BackwardTransitions = append(report.BackwardTransitions, ChurnTransition{From: t[0], To: t[1], Count: count})
+	}
+	sort.Slice(report.BackwardTransitions, func(i, j int) bool {
+		a, b := report.BackwardTransitions[i], report.BackwardTransitions[j]
+		if a.Count != b.Count {
+			return a.Count > b.Count
+		}
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		return a.To < b.To
+	})
+	return report
+}
+
+// FetchChurnReport fetches the issues matching the JQL query with their history and builds
+// their churn report. See BuildChurnReport.
+func FetchChurnReport(jiraURL, apikey, jql string, workflow []string, categories StatusCategories, minChanges, top int, verbose bool) (*ChurnReport, error) {
+	issues, err := FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	report := BuildChurnReport(issues, workflow, categories, minChanges, top)
+	report.JQL = jql
+	return report, nil
+}
+
+func printChurnTable(w *tabwriter.Writer, report *ChurnReport) {
+	fmt.Fprintln(w, "KEY\tSCORE\tBACKWARD\tREOPENS\tASSIGNEES\tPRIORITIES\tFLAGS\tSTATUS\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%d\t%s\t%s\t%s\n", issue.Key, issue.Score, issue.BackwardMoves, issue.Reopens,
+			issue.AssigneeChanges, issue.PriorityChanges, formatAgingFlags(issue.Flags), issue.Status, truncateSummary(issue.Summary))
+	}
+
+	if len(report.BackwardTransitions) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintln(w, "FROM\tTO\tMOVES")
+		for _, t := range report.BackwardTransitions {
+			fmt.Fprintf(w, "%s\t%s\t%d\n", t.From, t.To, t.Count)
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Flagged:\t%d of %d\n", report.Totals.Flagged, report.Totals.Issues)
+	fmt.Fprintf(w, "Backward moves:\t%d\n", report.Totals.BackwardMoves)
+	fmt.Fprintf(w, "Reopens:\t%d\n", report.Totals.Reopens)
+	fmt.Fprintf(w, "Assignee changes:\t%d\n", report.Totals.AssigneeChanges)
+	fmt.Fprintf(w, "Priority changes:\t%d\n", report.Totals.PriorityChanges)
+}
+
+func writeChurnMarkdown(b *strings.Builder, report *ChurnReport) {
+	t := report.Totals
+	b.WriteString("# Churn report\n\n")
+	fmt.Fprintf(b, "%d of %d issues flagged: %d backward moves, %d reopens, %d assignee changes and %d priority changes.\n\n",
+		t.Flagged, t.Issues, t.BackwardMoves, t.Reopens, t.AssigneeChanges, t.PriorityChanges)
+
+	if len(report.Issues) > 0 {
+		b.WriteString("| Key | Score | Backward | Reopens | Assignees | Priorities | Flags | Status | Summary |\n")
+		b.WriteString("|-----|-------|----------|---------|-----------|------------|-------|--------|---------|\n")
+		for _, issue := range report.Issues {
+			fmt.Fprintf(b, "| %s | %d | %d | %d | %d | %d | %s | %s | %s |\n", issue.Key, issue.Score, issue.BackwardMoves,
+				issue.Reopens, issue.AssigneeChanges, issue.PriorityChanges, formatAgingFlags(issue.Flags),
+				escapeMarkdown(issue.Status), escapeMarkdown(issue.Summary))
+		}
+	}
+
+	if len(report.BackwardTransitions) > 0 {
+		b.WriteString("\n## Backward transitions\n\n")
+		b.WriteString("| From | To | Moves |\n")
+		b.WriteString("|------|----|-------|\n")
+		for _, tr := range report.BackwardTransitions {
+			fmt.Fprintf(b, "| %s | %s | %d |\n", escapeMarkdown(tr.From), escapeMarkdown(tr.To), tr.Count)
+		}
+	}
+}
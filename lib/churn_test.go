@@ -0,0 +1,105 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildChurnReport(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	change := func(d int, field, from, to string) HistoryItem {
+		return HistoryItem{Created: day(d), Items: []HistoryChange{{Field: field, FromString: from, ToString: to}}}
+	}
+	issues := []Issue{
+		{
+			// Sent back from QE once and reopened after it was done
+			Key: "TEST-1", Status: Status{Name: "Dev"},
+			History: []HistoryItem{
+				change(1, "status", "New", "Dev"),
+				change(2, "status", "Dev", "QE"),
+				change(3, "status", "QE", "Dev"),
+				change(4, "status", "Dev", "QE"),
+				change(5, "status", "QE", "Done"),
+				change(6, "status", "Done", "Dev"),
+			},
+		},
+		{
+			// Assigned once and reassigned three times
+			Key: "TEST-2", Status: Status{Name: "New"}, Assignee: &User{DisplayName: "Dave"},
+			History: []HistoryItem{
+				change(1, "assignee", "", "Alice"),
+				change(2, "assignee", "Alice", "Bob"),
+				change(3, "assignee", "Bob", "Carol"),
+				change(4, "assignee", "Carol", "Dave"),
+				change(5, "priority", "Major", "Critical"),
+			},
+		},
+		{Key: "TEST-3", Status: Status{Name: "Dev"}, History: []HistoryItem{change(1, "status", "New", "Dev")}},
+		{
+			// Statuses outside the workflow are compared by category
+			Key: "TEST-4", Status: Status{Name: "New"},
+			History: []HistoryItem{
+				change(1, "status", "Review", "In Progress"),
+				change(2, "status", "In Progress", "Closed"),
+				change(3, "status", "Closed", "New"),
+			},
+		},
+	}
+	workflow := []string{"New", "Dev", "QE", "Done"}
+	categories := StatusCategories{"dev": CategoryInProgress, "qe": CategoryInProgress, "review": CategoryInProgress, "in progress": CategoryInProgress}
+
+	report := BuildChurnReport(issues, workflow, categories, DefaultChurnMinChanges, 0)
+	assert.Equal(t, ChurnTotals{Issues: 4, Flagged: 3, BackwardMoves: 3, Reopens: 2, AssigneeChanges: 3, PriorityChanges: 1}, report.Totals)
+	assert.Len(t, report.Issues, 3)
+
+	assert.Equal(t, "TEST-2", report.Issues[0].Key)
+	assert.Equal(t, "Dave", report.Issues[0].Assignee)
+	assert.Equal(t, 3, report.Issues[0].AssigneeChanges)
+	assert.Equal(t, 4, report.Issues[0].Score)
+	assert.Equal(t, []string{ChurnFlagReassigned}, report.Issues[0].Flags)
+
+	assert.Equal(t, "TEST-1", report.Issues[1].Key)
+	assert.Equal(t, 2, report.Issues[1].BackwardMoves)
+	assert.Equal(t, 1, report.Issues[1].Reopens)
+	assert.Equal(t, 2, report.Issues[1].Score)
+	assert.Equal(t, []string{ChurnFlagBackward, ChurnFlagReopened}, report.Issues[1].Flags)
+
+	assert.Equal(t, "TEST-4", report.Issues[2].Key)
+	assert.Equal(t, 1, report.Issues[2].Score)
+	assert.Equal(t, []string{ChurnFlagReopened}, report.Issues[2].Flags)
+
+	assert.Equal(t, []ChurnTransition{
+		{From: "Closed", To: "New", Count: 1},
+		{From: "Done", To: "Dev", Count: 1},
+		{From: "QE", To: "Dev", Count: 1},
+	}, report.BackwardTransitions)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+
+	top := BuildChurnReport(issues, workflow, categories, DefaultChurnMinChanges, 1)
+	assert.Len(t, top.Issues, 1)
+	assert.Equal(t, 4, top.Totals.Issues)
+}
+
+func TestIsBackwardMove(t *testing.T) {
+	categories := StatusCategories{"dev": CategoryInProgress, "qe": CategoryInProgress}
+	workflow := []string{"Dev", "QE"}
+
+	assert.True(t, isBackwardMove("qe", "DEV", workflow, categories))
+	assert.False(t, isBackwardMove("Dev", "QE", workflow, categories))
+	// Without a workflow both are in progress
+	assert.False(t, isBackwardMove("QE", "Dev", nil, categories))
+	assert.True(t, isBackwardMove("Done", "Dev", nil, categories))
+	assert.True(t, isBackwardMove("Dev", "New", nil, categories))
+}
+
+func TestFetchChurnReport_Validation(t *testing.T) {
+	_, err := FetchChurnReport("", "", "project = TEST", nil, nil, DefaultChurnMinChanges, 0, false)
+	assert.Error(t, err)
+	_, err = FetchChurnReport("https://jira.example.com", "token", "", nil, nil, DefaultChurnMinChanges, 0, false)
+	assert.Error(t, err)
+}
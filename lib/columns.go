@@ -0,0 +1,193 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// issueColumn describes one selectable column of the issue table
+type issueColumn struct {
+	header string
+	value  func(Issue) string
+}
+
+// issueColumns lists the columns available to PrintIssueTable, keyed by column name
+var issueColumns = map[string]issueColumn{
+	"key":      {"KEY", func(i Issue) string { return i.Key }},
+	"status":   {"STATUS", func(i Issue) string { return i.Status.Name }},
+	"priority": {"PRIORITY", func(i Issue) string { return i.Priority.Name }},
+	"type":     {"TYPE", func(i Issue) string { return i.IssueType.Name }},
+	"assignee": {"ASSIGNEE", func(i Issue) string {
+		if i.Assignee == nil {
+			return ""
+		}
+		return i.Assignee.DisplayName
+	}},
+	"estimate": {"ESTIMATE", func(i Issue) string {
+		if i.TimeTracking == nil {
+			return ""
+		}
+		return i.TimeTracking.OriginalEstimate
+	}},
+	"spent": {"SPENT", func(i Issue) string {
+		if i.TimeTracking == nil {
+			return ""
+		}
+		return i.TimeTracking.TimeSpent
+	}},
+	"points":      {"POINTS", func(i Issue) string { return strconv.FormatFloat(i.StoryPoints, 'f', -1, 64) }},
+	"fixversions": {"FIX VERSIONS", func(i Issue) string { return versionNames(i.FixVersions) }},
+	"versions":    {"AFFECTS VERSIONS", func(i Issue) string { return versionNames(i.AffectsVersions) }},
+	"watches":     {"WATCHES", func(i Issue) string { return strconv.Itoa(i.Watches) }},
+	"votes":       {"VOTES", func(i Issue) string { return strconv.Itoa(i.Votes) }},
+	"created":     {"CREATED", func(i Issue) string { return i.Created }},
+	"updated":     {"UPDATED", func(i Issue) string { return i.Updated }},
+	"resolved":    {"RESOLVED", func(i Issue) string { return i.Resolved }},
+	"summary":     {"SUMMARY", func(i Issue) string { return truncateSummary(i.Summary) }},
+}
+
+// DefaultIssueColumns are the columns printed by PrintTable for issue lists
+var DefaultIssueColumns = []string{"key", "status", "priority", "estimate", "spent", "summary"}
+
+// IssueColumnNames returns the names accepted by PrintIssueTable in a stable order
+func IssueColumnNames() []string {
+	return []string{
+		"key", "status", "priority", "type", "assignee", "estimate", "spent", "points",
+		"fixversions", "versions", "watches", "votes", "created", "updated", "resolved", "summary",
+	}
+}
+
+// versionNames joins version names for display
+func versionNames(versions []Version) string {
+	names := make([]string, 0, len(versions))
+	for _, v := range versions {
+		names = append(names, v.Name)
+	}
+	return strings.Join(names, ", ")
+}
+
+// issuesOf returns the issues held by an issue-list result type
+func issuesOf(data interface{}) ([]Issue, bool) {
+	switch v := data.(type) {
+	case []AssignedIssuesResult:
+		var all []Issue
+		for _, r := range v {
+			all = append(all, r.Issues...)
+		}
+		return all, true
+	case *QueryResult:
+		if v == nil {
+			return nil, true
+		}
+		return v.Issues, true
+	case *UserUpdatesResult:
+		if v == nil {
+			return nil, true
+		}
+		return v.Issues, true
+	case *TeamAssignedIssuesResult:
+		if v == nil {
+			return nil, true
+		}
+		return issuesOf(v.Members)
+	case *TeamUserUpdatesResult:
+		if v == nil {
+			return nil, true
+		}
+		var all []Issue
+		for _, r := range v.Members {
+			all = append(all, r.Issues...)
+		}
+		return all, true
+	}
+	return nil, false
+}
+
+// printIssueListTable prints the issues of an issue-list result type, followed by the
+// per-member totals for team results. It reports false for other data types.
+func printIssueListTable(w *tabwriter.Writer, data interface{}, columns []string) bool {
+	issues, ok := issuesOf(data)
+	if !ok {
+		return false
+	}
+	printIssueTable(w, issues, columns)
+
+	switch v := data.(type) {
+	case *TeamAssignedIssuesResult:
+		if v != nil {
+			printTeamSummaryTable(w, v.Team, v.Summary)
+		}
+	case *TeamUserUpdatesResult:
+		if v != nil {
+			printTeamSummaryTable(w, v.Team, v.Summary)
+		}
+	}
+	return true
+}
+
+// PrintIssueTable prints issues as a table with the given columns.
+// Accepts []AssignedIssuesResult, *UserUpdatesResult, *QueryResult, *TeamAssignedIssuesResult,
+// or *TeamUserUpdatesResult.
+func PrintIssueTable(data interface{}, columns []string) error {
+	if _, ok := issuesOf(data); !ok {
+		return fmt.Errorf("unsupported data type for issue table output: %T", data)
+	}
+	for _, c := range columns {
+		if _, ok := issueColumns[strings.ToLower(c)]; !ok {
+			return fmt.Errorf("unknown column %q (valid columns: %s)", c, strings.Join(IssueColumnNames(), ", "))
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	printIssueListTable(w, data, columns)
+	return w.Flush()
+}
+
+// printIssueTable prints one row per issue with the given columns, which must be valid
+func printIssueTable(w *tabwriter.Writer, issues []Issue, columns []string) {
+	headers := make([]string, len(columns))
+	for i, c := range columns {
+		headers[i] = issueColumns[strings.ToLower(c)].header
+	}
+	fmt.Fprintln(w, strings.Join(headers, "\t"))
+
+	for _, issue := range issues {
+		printIssueRow(w, issue, columns)
+	}
+	if len(issues) == 0 {
+		return
+	}
+
+	// Add a total row when the table shows time tracking columns
+	totals := SumTimeTracking(issues)
+	cells := make([]string, len(columns))
+	hasTotals := false
+	for i, c := range columns {
+		switch strings.ToLower(c) {
+		case "estimate":
+			cells[i] = totals.OriginalEstimate
+			hasTotals = true
+		case "spent":
+			cells[i] = totals.TimeSpent
+			hasTotals = true
+		}
+	}
+	if !hasTotals {
+		return
+	}
+	if cells[0] == "" {
+		cells[0] = "TOTAL"
+	}
+	fmt.Fprintln(w, strings.Join(cells, "\t"))
+}
+
+func printIssueRow(w *tabwriter.Writer, issue Issue, columns []string) {
+	cells := make([]string, len(columns))
+	for i, c := range columns {
+		cells[i] = issueColumns[strings.ToLower(c)].value(issue)
+	}
+	fmt.Fprintln(w, strings.Join(cells, "\t"))
+}
@@ -0,0 +1,38 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintIssueTable_CustomColumns(t *testing.T) {
+	result := &QueryResult{
+		Issues: []Issue{
+			{
+				Key:         "TEST-1",
+				Summary:     "Release blocker",
+				FixVersions: []Version{{Name: "4.16"}, {Name: "4.17"}},
+				Watches:     3,
+				Votes:       2,
+			},
+		},
+	}
+
+	assert.NoError(t, PrintIssueTable(result, []string{"key", "fixversions", "watches", "votes"}))
+}
+
+func TestPrintIssueTable_UnknownColumn(t *testing.T) {
+	err := PrintIssueTable(&QueryResult{}, []string{"key", "bogus"})
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "bogus")
+}
+
+func TestPrintIssueTable_UnsupportedType(t *testing.T) {
+	assert.Error(t, PrintIssueTable("not issues", []string{"key"}))
+}
+
+func TestVersionNames(t *testing.T) {
+	assert.Equal(t, "", versionNames(nil))
+	assert.Equal(t, "4.16, 4.17", versionNames([]Version{{Name: "4.16"}, {Name: "4.17"}}))
+}
@@ -0,0 +1,242 @@
+package lib
+
+import (
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PrintCSV prints data as comma-separated values to stdout.
+// Accepts []AssignedIssuesResult, *UserUpdatesResult, *QueryResult, *TeamAssignedIssuesResult,
+// *TeamUserUpdatesResult, *ActivityReport, *TimesheetReport, *TimeInStatusReport, *FlowReport,
+// *ThroughputReport, *CFDReport, *AgingReport, *BurndownReport, *SLAReport, *WorkloadReport,
+// *ChurnReport, or *StaleReport.
+func PrintCSV(data interface{}) error {
+	var records [][]string
+
+	switch v := data.(type) {
+	case []AssignedIssuesResult:
+		records = append(records, userIssueCSVHeader)
+		for _, r := range v {
+			records = append(records, userIssueCSVRecords(r.User, r.Issues)...)
+		}
+	case *TeamAssignedIssuesResult:
+		records = append(records, userIssueCSVHeader)
+		if v != nil {
+			for _, r := range v.Members {
+				records = append(records, userIssueCSVRecords(r.User, r.Issues)...)
+			}
+		}
+	case *TeamUserUpdatesResult:
+		records = append(records, userIssueCSVHeader)
+		if v != nil {
+			for _, r := range v.Members {
+				records = append(records, userIssueCSVRecords(r.User, r.Issues)...)
+			}
+		}
+	case *QueryResult:
+		records = append(records, issueCSVHeader)
+		if v != nil {
+			records = append(records, issueCSVRecords(v.Issues)...)
+		}
+	case *UserUpdatesResult:
+		records = append(records, issueCSVHeader)
+		if v != nil {
+			records = append(records, issueCSVRecords(v.Issues)...)
+		}
+	case *ActivityReport:
+		records = append(records, []string{"user", "time", "key", "action", "from", "to", "detail", "summary"})
+		if v != nil {
+			for _, u := range v.Users {
+				for _, e := range u.Events {
+					records = append(records, []string{
+						u.User, e.At.Format(time.RFC3339), e.IssueKey, e.Action, e.From, e.To, e.Detail, e.Summary,
+					})
+				}
+			}
+		}
+	case *TimesheetReport:
+		records = append(records, []string{"user", "date", "key", "summary", "seconds", "hours"})
+		if v != nil {
+			for _, e := range v.Entries {
+				records = append(records, []string{
+					e.User, e.Date, e.IssueKey, e.Summary,
+					strconv.Itoa(e.Seconds), formatCSVFloat(e.Hours),
+				})
+			}
+		}
+	case *TimeInStatusReport:
+		records = append(records, []string{"key", "summary", "status", "hours", "visits"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				for _, s := range issue.Statuses {
+					records = append(records, []string{
+						issue.Key, issue.Summary, s.Status, formatCSVFloat(s.Hours), strconv.Itoa(s.Visits),
+					})
+				}
+			}
+		}
+	case *FlowReport:
+		records = append(records, []string{"key", "issueType", "created", "started", "resolved", "leadTimeDays", "cycleTimeDays", "summary"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				cycleTime := ""
+				if issue.CycleTimeDays != nil {
+					cycleTime = formatCSVFloat(*issue.CycleTimeDays)
+				}
+				records = append(records, []string{
+					issue.Key, issue.IssueType, issue.Created, issue.Started, issue.Resolved,
+					formatCSVFloat(issue.LeadTimeDays), cycleTime, issue.Summary,
+				})
+			}
+		}
+	case *ThroughputReport:
+		records = append(records, []string{"start", "end", "resolved"})
+		if v != nil {
+			for _, interval := range v.Intervals {
+				records = append(records, []string{interval.Start, interval.End, strconv.Itoa(interval.Count)})
+			}
+		}
+	case *CFDReport:
+		if v == nil {
+			records = append(records, []string{"date"})
+			break
+		}
+		records = append(records, append([]string{"date"}, v.Statuses...))
+		for _, day := range v.Days {
+			record := []string{day.Date}
+			for _, status := range v.Statuses {
+				record = append(record, strconv.Itoa(day.Counts[status]))
+			}
+			records = append(records, record)
+		}
+	case *AgingReport:
+		records = append(records, []string{"key", "status", "assignee", "statusSince", "started", "statusAgeDays", "workAgeDays", "flags", "summary"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				records = append(records, []string{
+					issue.Key, issue.Status, issue.Assignee, issue.StatusSince, issue.Started,
+					formatCSVFloat(issue.StatusAgeDays), formatCSVFloat(issue.WorkAgeDays),
+					strings.Join(issue.Flags, ";"), issue.Summary,
+				})
+			}
+		}
+	case *BurndownReport:
+		records = append(records, []string{"date", "ideal", "remaining", "scope", "completed"})
+		if v != nil {
+			actual := func(value *float64) string {
+				if value == nil {
+					return ""
+				}
+				return formatCSVFloat(*value)
+			}
+			for _, day := range v.Days {
+				records = append(records, []string{
+					day.Date, formatCSVFloat(day.Ideal), actual(day.Remaining), actual(day.Scope), actual(day.Completed),
+				})
+			}
+		}
+	case *SLAReport:
+		records = append(records, []string{"key", "priority", "issueType", "rule", "target", "state", "targetHours", "elapsedHours", "at", "assignee", "summary"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				for _, t := range []struct {
+					name   string
+					target *SLATarget
+				}{{"firstResponse", issue.FirstResponse}, {"resolution", issue.Resolution}} {
+					if t.target == nil {
+						continue
+					}
+					records = append(records, []string{
+						issue.Key, issue.Priority, issue.IssueType, issue.Rule, t.name, t.target.State,
+						formatCSVFloat(t.target.TargetHours), formatCSVFloat(t.target.ElapsedHours), t.target.At,
+						issue.Assignee, issue.Summary,
+					})
+				}
+			}
+		}
+	case *WorkloadReport:
+		header := []string{"user", "open", "wip", "remainingEstimate", "remainingEstimateSeconds", "flags"}
+		if v == nil {
+			records = append(records, header)
+			break
+		}
+		for _, p := range v.Priorities {
+			header = append(header, "priority:"+p)
+		}
+		for _, t := range v.IssueTypes {
+			header = append(header, "type:"+t)
+		}
+		records = append(records, header)
+		for _, m := range v.Members {
+			record := []string{
+				m.User, strconv.Itoa(m.Open), strconv.Itoa(m.WIP), m.RemainingEstimate,
+				strconv.Itoa(m.RemainingEstimateSeconds), strings.Join(m.Flags, ";"),
+			}
+			for _, p := range v.Priorities {
+				record = append(record, strconv.Itoa(m.ByPriority[p]))
+			}
+			for _, t := range v.IssueTypes {
+				record = append(record, strconv.Itoa(m.ByType[t]))
+			}
+			records = append(records, record)
+		}
+	case *ChurnReport:
+		records = append(records, []string{"key", "score", "backwardMoves", "reopens", "assigneeChanges", "priorityChanges", "flags", "status", "assignee", "summary"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				records = append(records, []string{
+					issue.Key, strconv.Itoa(issue.Score), strconv.Itoa(issue.BackwardMoves), strconv.Itoa(issue.Reopens),
+					strconv.Itoa(issue.AssigneeChanges), strconv.Itoa(issue.PriorityChanges),
+					strings.Join(issue.Flags, ";"), issue.Status, issue.Assignee, issue.Summary,
+				})
+			}
+		}
+	case *StaleReport:
+		records = append(records, []string{"key", "idleDays", "lastActivity", "lastActivityBy", "lastAction", "status", "assignee", "components", "summary"})
+		if v != nil {
+			for _, issue := range v.Issues {
+				records = append(records, []string{
+					issue.Key, formatCSVFloat(issue.IdleDays), issue.LastActivity, issue.LastActivityBy, issue.LastAction,
+					issue.Status, issue.Assignee, strings.Join(issue.Components, ";"), issue.Summary,
+				})
+			}
+		}
+	default:
+		return fmt.Errorf("unsupported data type for CSV output: %T", data)
+	}
+
+	w := csv.NewWriter(os.Stdout)
+	if err := w.WriteAll(records); err != nil {
+		return fmt.Errorf("writing CSV: %w", err)
+	}
+	return nil
+}
+
+var issueCSVHeader = []string{"key", "status", "priority", "summary"}
+
+func issueCSVRecords(issues []Issue) [][]string {
+	var records [][]string
+	for _, issue := range issues {
+		records = append(records, []string{issue.Key, issue.Status.Name, issue.Priority.Name, issue.Summary})
+	}
+	return records
+}
+
+var userIssueCSVHeader = []string{"user", "key", "status", "priority", "summary"}
+
+func userIssueCSVRecords(user string, issues []Issue) [][]string {
+	var records [][]string
+	for _, issue := range issues {
+		records = append(records, []string{user, issue.Key, issue.Status.Name, issue.Priority.Name, issue.Summary})
+	}
+	return records
+}
+
+// formatCSVFloat formats a number without trailing zeros for spreadsheet import
+func formatCSVFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
@@ -0,0 +1,23 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintCSV_QueryResult(t *testing.T) {
+	data := &QueryResult{
+		JQL: "project = CNF",
+		Issues: []Issue{
+			{Key: "CNF-1234", Summary: "Summary, with comma", Status: Status{Name: "Open"}},
+		},
+	}
+	assert.NoError(t, PrintCSV(data))
+}
+
+func TestPrintCSV_UnsupportedType(t *testing.T) {
+	err := PrintCSV("unsupported")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported data type")
+}
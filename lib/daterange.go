@@ -0,0 +1,221 @@
+package lib
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// jqlTimeLayout is the layout of date-time values in JQL
+const jqlTimeLayout = "2006-01-02 15:04"
+
+var (
+	timezone   = time.Local
+	timezoneMu sync.RWMutex
+
+	// sprintDates resolves "this-sprint" to the dates of the active sprint; nil means unsupported
+	sprintDates func() (start, end time.Time, err error)
+)
+
+// SetTimezone sets the timezone in which dates and day boundaries are interpreted.
+// It should match the timezone of the Jira user profile, which Jira uses to evaluate JQL.
+func SetTimezone(loc *time.Location) {
+	if loc == nil {
+		loc = time.Local
+	}
+	timezoneMu.Lock()
+	defer timezoneMu.Unlock()
+	timezone = loc
+}
+
+// GetTimezone returns the timezone in which dates are interpreted
+func GetTimezone() *time.Location {
+	timezoneMu.RLock()
+	defer timezoneMu.RUnlock()
+	return timezone
+}
+
+// SetSprintDateResolver sets the function that resolves "this-sprint" to the start and end
+// of the active sprint
+func SetSprintDateResolver(resolve func() (start, end time.Time, err error)) {
+	sprintDates = resolve
+}
+
+// DateRange is a half-open time interval [Start, End)
+type DateRange struct {
+	Start time.Time `json:"start" yaml:"start"`
+	End   time.Time `json:"end" yaml:"end"`
+}
+
+var (
+	relativeDatePattern = regexp.MustCompile(`^-(\d+)([hdw])$`)
+	isoWeekPattern      = regexp.MustCompile(`^(\d{4})-W(\d{2})$`)
+	quarterPattern      = regexp.MustCompile(`^(\d{4})-Q([1-4])$`)
+)
+
+// ParseDateRange parses the start and end of a date range relative to now, in the configured
+// timezone. Each value may be a date (2024-01-31), an RFC3339 timestamp or a date with a time
+// of day (2024-01-31T17:00), a relative offset (-7d, -12h, -2w), a keyword (now, today,
+// yesterday, this-week, last-week, this-month, last-month, this-sprint), an ISO week
+// (2024-W03) or a quarter (2024-Q1). A value covering a period starts the range at the
+// beginning of the period when used as start, and ends it at the end of the period when
+// used as end, so "2024-01-31" as end includes that whole day.
+func ParseDateRange(start, end string, now time.Time) (DateRange, error) {
+	if start == "" || end == "" {
+		return DateRange{}, fmt.Errorf("start and end dates must be provided")
+	}
+
+	from, _, err := parseDateExpr(start, now)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("invalid start date: %w", err)
+	}
+	_, to, err := parseDateExpr(end, now)
+	if err != nil {
+		return DateRange{}, fmt.Errorf("invalid end date: %w", err)
+	}
+	if !from.Before(to) {
+		return DateRange{}, fmt.Errorf("start %s is not before end %s", start, end)
+	}
+	return DateRange{Start: from, End: to}, nil
+}
+
+// parseDateExpr returns the period [from, to) denoted by a date expression
+func parseDateExpr(expr string, now time.Time) (from, to time.Time, err error) {
+	loc := GetTimezone()
+	now = now.In(loc)
+	today := startOfDay(now)
+	value := strings.ToLower(strings.TrimSpace(expr))
+
+	switch value {
+	case "now":
+		return now, now.Add(time.Minute), nil
+	case "today":
+		return today, today.AddDate(0, 0, 1), nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), today, nil
+	case "this-week":
+		monday := startOfWeek(today)
+		return monday, monday.AddDate(0, 0, 7), nil
+	case "last-week":
+		monday := startOfWeek(today)
+		return monday.AddDate(0, 0, -7), monday, nil
+	case "this-month":
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+		return first, first.AddDate(0, 1, 0), nil
+	case "last-month":
+		first := time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, loc)
+		return first.AddDate(0, -1, 0), first, nil
+	case "this-sprint":
+		if sprintDates == nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("this-sprint requires a board (set one with config set --board)")
+		}
+		start, end, err := sprintDates()
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("resolving this-sprint: %w", err)
+		}
+		return start.In(loc), end.In(loc), nil
+	}
+
+	if m := relativeDatePattern.FindStringSubmatch(value); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2] {
+		case "h":
+			t := now.Add(-time.Duration(n) * time.Hour)
+			return t, t.Add(time.Minute), nil
+		case "w":
+			n *= 7
+		}
+		day := today.AddDate(0, 0, -n)
+		return day, day.AddDate(0, 0, 1), nil
+	}
+	if m := isoWeekPattern.FindStringSubmatch(strings.ToUpper(value)); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		week, _ := strconv.Atoi(m[2])
+		if week < 1 || week > isoWeeksInYear(year) {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid ISO week %q", expr)
+		}
+		monday := isoWeekStart(year, loc).AddDate(0, 0, (week-1)*7)
+		return monday, monday.AddDate(0, 0, 7), nil
+	}
+	if m := quarterPattern.FindStringSubmatch(strings.ToUpper(value)); m != nil {
+		year, _ := strconv.Atoi(m[1])
+		quarter, _ := strconv.Atoi(m[2])
+		first := time.Date(year, time.Month((quarter-1)*3+1), 1, 0, 0, 0, 0, loc)
+		return first, first.AddDate(0, 3, 0), nil
+	}
+	if t, err := time.ParseInLocation("2006-01-02", value, loc); err == nil {
+		return t, t.AddDate(0, 0, 1), nil
+	}
+	if t, err := time.Parse(time.RFC3339, strings.ToUpper(value)); err == nil {
+		t = t.In(loc)
+		return t, t.Add(time.Minute), nil
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if t, err := time.ParseInLocation(layout, strings.ToUpper(value), loc); err == nil {
+			return t, t.Add(time.Minute), nil
+		}
+	}
+	return time.Time{}, time.Time{}, fmt.Errorf("unrecognized date %q (use YYYY-MM-DD, RFC3339, -7d, today, yesterday, last-week, this-sprint, 2024-W03 or 2024-Q1)", expr)
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday starting the ISO week of day
+func startOfWeek(day time.Time) time.Time {
+	return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+}
+
+// isoWeekStart returns the Monday of ISO week 1, the week containing January 4th
+func isoWeekStart(year int, loc *time.Location) time.Time {
+	return startOfWeek(time.Date(year, time.January, 4, 0, 0, 0, 0, loc))
+}
+
+// isoWeeksInYear returns the number of ISO weeks in year (52 or 53);
+// 28 December always falls in the last week
+func isoWeeksInYear(year int) int {
+	_, week := time.Date(year, time.December, 28, 0, 0, 0, 0, time.UTC).ISOWeek()
+	return week
+}
+
+// isMidnight reports whether t is the start of a day
+func isMidnight(t time.Time) bool {
+	return t.Equal(startOfDay(t))
+}
+
+// JQL returns a JQL clause selecting values of a date field within the range
+func (r DateRange) JQL(field string) string {
+	loc := GetTimezone()
+	return fmt.Sprintf(`%s >= "%s" AND %s < "%s"`,
+		field, r.Start.In(loc).Format(jqlTimeLayout), field, r.End.In(loc).Format(jqlTimeLayout))
+}
+
+// Contains reports whether t falls within the range
+func (r DateRange) Contains(t time.Time) bool {
+	return !t.Before(r.Start) && t.Before(r.End)
+}
+
+// FirstDay returns the first calendar day touched by the range
+func (r DateRange) FirstDay() string {
+	return r.Start.In(GetTimezone()).Format("2006-01-02")
+}
+
+// LastDay returns the last calendar day touched by the range
+func (r DateRange) LastDay() string {
+	return r.End.In(GetTimezone()).Add(-time.Nanosecond).Format("2006-01-02")
+}
+
+// String formats the range as "2024-01-01 to 2024-01-31" for whole days, or with times of
+// day otherwise
+func (r DateRange) String() string {
+	loc := GetTimezone()
+	start, end := r.Start.In(loc), r.End.In(loc)
+	if isMidnight(start) && isMidnight(end) {
+		return fmt.Sprintf("%s to %s", r.FirstDay(), r.LastDay())
+	}
+	return fmt.Sprintf("%s to %s", start.Format(jqlTimeLayout), end.Format(jqlTimeLayout))
+}
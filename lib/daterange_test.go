@@ -0,0 +1,102 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDateRange(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	// Wednesday
+	now := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+	day := func(y int, m time.Month, d int) time.Time { return time.Date(y, m, d, 0, 0, 0, 0, time.UTC) }
+
+	tests := []struct {
+		start, end string
+		want       DateRange
+	}{
+		{"2024-01-01", "2024-01-31", DateRange{day(2024, 1, 1), day(2024, 2, 1)}},
+		{"-7d", "today", DateRange{day(2024, 1, 10), day(2024, 1, 18)}},
+		{"yesterday", "yesterday", DateRange{day(2024, 1, 16), day(2024, 1, 17)}},
+		{"last-week", "last-week", DateRange{day(2024, 1, 8), day(2024, 1, 15)}},
+		{"this-week", "now", DateRange{day(2024, 1, 15), now.Add(time.Minute)}},
+		{"2024-W03", "2024-W03", DateRange{day(2024, 1, 15), day(2024, 1, 22)}},
+		{"2020-W53", "2020-W53", DateRange{day(2020, 12, 28), day(2021, 1, 4)}},
+		{"2024-Q1", "2024-Q1", DateRange{day(2024, 1, 1), day(2024, 4, 1)}},
+		{"last-month", "last-month", DateRange{day(2023, 12, 1), day(2024, 1, 1)}},
+		{"-12h", "now", DateRange{now.Add(-12 * time.Hour), now.Add(time.Minute)}},
+		{"2024-01-15T09:00:00Z", "2024-01-15T17:00", DateRange{
+			time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC),
+			time.Date(2024, 1, 15, 17, 1, 0, 0, time.UTC),
+		}},
+	}
+	for _, tt := range tests {
+		got, err := ParseDateRange(tt.start, tt.end, now)
+		assert.NoError(t, err, "%s..%s", tt.start, tt.end)
+		assert.True(t, tt.want.Start.Equal(got.Start), "%s..%s start: got %s", tt.start, tt.end, got.Start)
+		assert.True(t, tt.want.End.Equal(got.End), "%s..%s end: got %s", tt.start, tt.end, got.End)
+	}
+}
+
+func TestParseDateRange_Errors(t *testing.T) {
+	now := time.Date(2024, 1, 17, 15, 30, 0, 0, time.UTC)
+
+	_, err := ParseDateRange("2024-02-01", "2024-01-01", now)
+	assert.ErrorContains(t, err, "not before")
+
+	_, err = ParseDateRange("invalid-date", "2024-01-31", now)
+	assert.Error(t, err)
+
+	_, err = ParseDateRange("2024-W60", "2024-W60", now)
+	assert.Error(t, err)
+
+	// 2023 has 52 ISO weeks; W53 would silently become 2024-W01
+	_, err = ParseDateRange("2023-W53", "2023-W53", now)
+	assert.ErrorContains(t, err, "invalid ISO week")
+
+	SetSprintDateResolver(nil)
+	_, err = ParseDateRange("this-sprint", "this-sprint", now)
+	assert.ErrorContains(t, err, "board")
+}
+
+func TestParseDateRange_ThisSprint(t *testing.T) {
+	start := time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 22, 9, 0, 0, 0, time.UTC)
+	SetSprintDateResolver(func() (time.Time, time.Time, error) { return start, end, nil })
+	defer SetSprintDateResolver(nil)
+
+	got, err := ParseDateRange("this-sprint", "this-sprint", time.Now())
+	assert.NoError(t, err)
+	assert.True(t, got.Start.Equal(start))
+	assert.True(t, got.End.Equal(end))
+}
+
+func TestParseDateRange_Timezone(t *testing.T) {
+	loc := time.FixedZone("UTC+2", 2*60*60)
+	SetTimezone(loc)
+	defer SetTimezone(nil)
+
+	got, err := ParseDateRange("2024-01-31", "2024-01-31", time.Now())
+	assert.NoError(t, err)
+	assert.True(t, got.Start.Equal(time.Date(2024, 1, 30, 22, 0, 0, 0, time.UTC)))
+	assert.Equal(t, `updated >= "2024-01-31 00:00" AND updated < "2024-02-01 00:00"`, got.JQL("updated"))
+}
+
+func TestDateRangeString(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	whole := DateRange{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "2024-01-01 to 2024-01-31", whole.String())
+	assert.Equal(t, "2024-01-01", whole.FirstDay())
+	assert.Equal(t, "2024-01-31", whole.LastDay())
+	assert.True(t, whole.Contains(time.Date(2024, 1, 31, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, whole.Contains(whole.End))
+
+	partial := DateRange{time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC), time.Date(2024, 1, 15, 17, 0, 0, 0, time.UTC)}
+	assert.Equal(t, "2024-01-15 09:00 to 2024-01-15 17:00", partial.String())
+}
@@ -0,0 +1,200 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Jira's default time tracking configuration: an 8 hour day and a 5 day week
+const (
+	DefaultHoursPerDay = 8.0
+	DefaultDaysPerWeek = 5.0
+)
+
+var (
+	hoursPerDay = DefaultHoursPerDay
+	daysPerWeek = DefaultDaysPerWeek
+)
+
+// SetWorkingTime sets the working hours per day and days per week used to interpret
+// the "d" and "w" units of Jira duration strings. Non-positive values are ignored.
+func SetWorkingTime(hours, days float64) {
+	if hours > 0 {
+		hoursPerDay = hours
+	}
+	if days > 0 {
+		daysPerWeek = days
+	}
+}
+
+// GetWorkingTime returns the working hours per day and days per week
+func GetWorkingTime() (hours, days float64) {
+	return hoursPerDay, daysPerWeek
+}
+
+// durationUnit returns the length of one Jira duration unit
+func durationUnit(unit string) (time.Duration, bool) {
+	day := time.Duration(hoursPerDay * float64(time.Hour))
+	switch unit {
+	case "w":
+		return time.Duration(daysPerWeek * float64(day)), true
+	case "d":
+		return day, true
+	case "h":
+		return time.Hour, true
+	case "m":
+		return time.Minute, true
+	case "s":
+		return time.Second, true
+	}
+	return 0, false
+}
+
+// ParseJiraDuration parses a Jira duration string such as "1w 2d 3h 30m" into a
+// time.Duration, using the configured working hours per day and days per week.
+func ParseJiraDuration(value string) (time.Duration, error) {
+	return parseDurationUnits(value, "Jira duration", durationUnit)
+}
+
+// calendarDurationUnit returns the length of one unit of elapsed wall-clock time
+func calendarDurationUnit(unit string) (time.Duration, bool) {
+	switch unit {
+	case "w":
+		return 7 * 24 * time.Hour, true
+	case "d":
+		return 24 * time.Hour, true
+	}
+	return durationUnit(unit)
+}
+
+// ParseCalendarDuration parses a duration such as "1w 2d 3h" of elapsed wall-clock time,
+// with 24 hour days and 7 day weeks.
+func ParseCalendarDuration(value string) (time.Duration, error) {
+	return parseDurationUnits(value, "duration", calendarDurationUnit)
+}
+
+// parseDurationUnits sums the space-separated amounts of a duration string, each followed
+// by a unit known to unitOf. kind names the notation in errors.
+func parseDurationUnits(value, kind string, unitOf func(string) (time.Duration, bool)) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+
+	var total time.Duration
+	for _, part := range strings.Fields(value) {
+		unit, ok := unitOf(part[len(part)-1:])
+		if !ok {
+			return 0, fmt.Errorf("invalid %s %q: unknown unit in %q", kind, value, part)
+		}
+		amount, err := strconv.ParseFloat(part[:len(part)-1], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s %q: %w", kind, value, err)
+		}
+		total += time.Duration(amount * float64(unit))
+	}
+	return total, nil
+}
+
+// FormatJiraDuration formats a duration in Jira's "1w 2d 3h 30m" notation
+func FormatJiraDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "0m"
+	}
+
+	var parts []string
+	for _, unit := range []string{"w", "d", "h", "m"} {
+		size, _ := durationUnit(unit)
+		if n := d / size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit))
+			d -= n * size
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// formatCalendarDuration formats elapsed wall-clock time as "2d 3h 30m" with 24 hour days
+func formatCalendarDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "0m"
+	}
+
+	var parts []string
+	for _, unit := range []struct {
+		size time.Duration
+		name string
+	}{{24 * time.Hour, "d"}, {time.Hour, "h"}, {time.Minute, "m"}} {
+		if n := d / unit.size; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, unit.name))
+			d -= n * unit.size
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// trackedDuration prefers the seconds value reported by Jira, which already accounts
+// for the instance's time tracking settings, and falls back to parsing the string.
+func trackedDuration(seconds int, value string) time.Duration {
+	if seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	d, err := ParseJiraDuration(value)
+	if err != nil {
+		return 0
+	}
+	return d
+}
+
+// OriginalEstimateDuration returns the original estimate as a time.Duration
+func (tt *TimeTracking) OriginalEstimateDuration() time.Duration {
+	if tt == nil {
+		return 0
+	}
+	return trackedDuration(tt.OriginalEstimateSeconds, tt.OriginalEstimate)
+}
+
+// RemainingEstimateDuration returns the remaining estimate as a time.Duration
+func (tt *TimeTracking) RemainingEstimateDuration() time.Duration {
+	if tt == nil {
+		return 0
+	}
+	return trackedDuration(tt.RemainingEstimateSeconds, tt.RemainingEstimate)
+}
+
+// TimeSpentDuration returns the time spent as a time.Duration
+func (tt *TimeTracking) TimeSpentDuration() time.Duration {
+	if tt == nil {
+		return 0
+	}
+	return trackedDuration(tt.TimeSpentSeconds, tt.TimeSpent)
+}
+
+// EstimateTotals sums the time tracking of a set of issues
+type EstimateTotals struct {
+	OriginalEstimate         string `json:"originalEstimate" yaml:"originalEstimate"`
+	RemainingEstimate        string `json:"remainingEstimate" yaml:"remainingEstimate"`
+	TimeSpent                string `json:"timeSpent" yaml:"timeSpent"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds" yaml:"originalEstimateSeconds"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds" yaml:"remainingEstimateSeconds"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds" yaml:"timeSpentSeconds"`
+}
+
+// SumTimeTracking totals the original estimate, remaining estimate and time spent of the issues
+func SumTimeTracking(issues []Issue) EstimateTotals {
+	var original, remaining, spent time.Duration
+	for _, issue := range issues {
+		original += issue.TimeTracking.OriginalEstimateDuration()
+		remaining += issue.TimeTracking.RemainingEstimateDuration()
+		spent += issue.TimeTracking.TimeSpentDuration()
+	}
+	return EstimateTotals{
+		OriginalEstimate:         FormatJiraDuration(original),
+		RemainingEstimate:        FormatJiraDuration(remaining),
+		TimeSpent:                FormatJiraDuration(spent),
+		OriginalEstimateSeconds:  int(original.Seconds()),
+		RemainingEstimateSeconds: int(remaining.Seconds()),
+		TimeSpentSeconds:         int(spent.Seconds()),
+	}
+}
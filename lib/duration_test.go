@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJiraDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"":            0,
+		"30m":         30 * time.Minute,
+		"3h":          3 * time.Hour,
+		"1d":          8 * time.Hour,
+		"1w":          40 * time.Hour,
+		"1w 2d 3h":    59 * time.Hour,
+		"1.5h":        90 * time.Minute,
+		"2d 4h 15m":   20*time.Hour + 15*time.Minute,
+		" 1h  30m  ":  90 * time.Minute,
+		"1w 0d 0h 5m": 40*time.Hour + 5*time.Minute,
+	}
+	for input, expected := range tests {
+		d, err := ParseJiraDuration(input)
+		assert.NoError(t, err, input)
+		assert.Equal(t, expected, d, input)
+	}
+
+	for _, input := range []string{"3x", "h", "abc"} {
+		_, err := ParseJiraDuration(input)
+		assert.Error(t, err, input)
+	}
+}
+
+func TestParseCalendarDuration(t *testing.T) {
+	d, err := ParseCalendarDuration("1w 2d 4h")
+	assert.NoError(t, err)
+	assert.Equal(t, 220*time.Hour, d)
+
+	_, err = ParseCalendarDuration("5x")
+	assert.Error(t, err)
+}
+
+func TestParseJiraDuration_CustomWorkingTime(t *testing.T) {
+	SetWorkingTime(6, 4)
+	defer SetWorkingTime(DefaultHoursPerDay, DefaultDaysPerWeek)
+
+	d, err := ParseJiraDuration("1w 1d")
+	assert.NoError(t, err)
+	assert.Equal(t, 30*time.Hour, d)
+
+	hours, days := GetWorkingTime()
+	assert.Equal(t, 6.0, hours)
+	assert.Equal(t, 4.0, days)
+
+	// Non-positive values leave the setting unchanged
+	SetWorkingTime(0, -1)
+	hours, days = GetWorkingTime()
+	assert.Equal(t, 6.0, hours)
+	assert.Equal(t, 4.0, days)
+}
+
+func TestFormatJiraDuration(t *testing.T) {
+	assert.Equal(t, "0m", FormatJiraDuration(0))
+	assert.Equal(t, "1w 2d 3h", FormatJiraDuration(59*time.Hour))
+	assert.Equal(t, "1h 30m", FormatJiraDuration(90*time.Minute))
+}
+
+func TestTimeTrackingDurations(t *testing.T) {
+	tt := &TimeTracking{
+		OriginalEstimate:         "1d",
+		OriginalEstimateSeconds:  3600 * 6,
+		RemainingEstimate:        "2h",
+		TimeSpent:                "invalid",
+		TimeSpentSeconds:         0,
+		RemainingEstimateSeconds: 0,
+	}
+	// Seconds reported by Jira take precedence over the string form
+	assert.Equal(t, 6*time.Hour, tt.OriginalEstimateDuration())
+	assert.Equal(t, 2*time.Hour, tt.RemainingEstimateDuration())
+	assert.Equal(t, time.Duration(0), tt.TimeSpentDuration())
+
+	var missing *TimeTracking
+	assert.Equal(t, time.Duration(0), missing.OriginalEstimateDuration())
+}
+
+func TestSumTimeTracking(t *testing.T) {
+	issues := []Issue{
+		{Key: "CNF-1", TimeTracking: &TimeTracking{OriginalEstimate: "1d", TimeSpent: "4h"}},
+		{Key: "CNF-2", TimeTracking: &TimeTracking{OriginalEstimateSeconds: 7200, RemainingEstimate: "1h"}},
+		{Key: "CNF-3"},
+	}
+	totals := SumTimeTracking(issues)
+	assert.Equal(t, "1d 2h", totals.OriginalEstimate)
+	assert.Equal(t, 36000, totals.OriginalEstimateSeconds)
+	assert.Equal(t, "1h", totals.RemainingEstimate)
+	assert.Equal(t, "4h", totals.TimeSpent)
+}
+
+func TestConvertJiraIssue_TimeTrackingFromSeconds(t *testing.T) {
+	issue := convertJiraIssue(jira.Issue{
+		Key: "CNF-1",
+		Fields: &jira.IssueFields{
+			TimeOriginalEstimate: 2 * 8 * 3600,
+			TimeSpent:            3600,
+		},
+	})
+	assert.NotNil(t, issue.TimeTracking)
+	assert.Equal(t, "2d", issue.TimeTracking.OriginalEstimate)
+	assert.Equal(t, "1h", issue.TimeTracking.TimeSpent)
+	assert.Equal(t, "", issue.TimeTracking.RemainingEstimate)
+	assert.Equal(t, 16*time.Hour, issue.TimeTracking.OriginalEstimateDuration())
+}
@@ -20,6 +20,14 @@ func getHTTPClient(apikey string) *http.Client {
 	return tokenAuth.Client()
 }
 
+// authorName returns the identifier of an author: the Cloud account ID when present, otherwise the username
+func authorName(name, accountID string) string {
+	if accountID != "" {
+		return accountID
+	}
+	return name
+}
+
 // FetchIssueComments retrieves all comments for a specific issue
 // Uses Bearer token authentication from existing jira.Client
 func FetchIssueComments(client *jira.Client, baseURL, issueKey, apikey string) ([]Comment, error) {
@@ -52,6 +60,8 @@ func FetchIssueComments(client *jira.Client, baseURL, issueKey, apikey string) (
 			ID     string `json:"id"`
 			Body   string `json:"body"`
 			Author struct {
+				Name        string `json:"name"`
+				AccountID   string `json:"accountId"`
 				DisplayName string `json:"displayName"`
 			} `json:"author"`
 			Created string `json:"created"`
@@ -69,11 +79,12 @@ func FetchIssueComments(client *jira.Client, baseURL, issueKey, apikey string) (
 		updated, _ := time.Parse("2006-01-02T15:04:05.000-0700", c.Updated)
 
 		comments = append(comments, Comment{
-			ID:      c.ID,
-			Body:    c.Body,
-			Author:  c.Author.DisplayName,
-			Created: created,
-			Updated: updated,
+			ID:         c.ID,
+			Body:       c.Body,
+			Author:     c.Author.DisplayName,
+			AuthorName: authorName(c.Author.Name, c.Author.AccountID),
+			Created:    created,
+			Updated:    updated,
 		})
 	}
 
@@ -109,6 +120,8 @@ func FetchIssueHistory(client *jira.Client, baseURL, issueKey, apikey string) ([
 			Histories []struct {
 				ID     string `json:"id"`
 				Author struct {
+					Name        string `json:"name"`
+					AccountID   string `json:"accountId"`
 					DisplayName string `json:"displayName"`
 				} `json:"author"`
 				Created string `json:"created"`
@@ -141,10 +154,11 @@ func FetchIssueHistory(client *jira.Client, baseURL, issueKey, apikey string) ([
 		}
 
 		history = append(history, HistoryItem{
-			ID:      h.ID,
-			Author:  h.Author.DisplayName,
-			Created: created,
-			Items:   items,
+			ID:         h.ID,
+			Author:     h.Author.DisplayName,
+			AuthorName: authorName(h.Author.Name, h.Author.AccountID),
+			Created:    created,
+			Items:      items,
 		})
 	}
 
@@ -189,9 +203,12 @@ func FetchEnhancedFields(client *jira.Client, baseURL, issueKey, apikey string)
 				Name string `json:"name"`
 			} `json:"issuetype"`
 			TimeTracking struct {
-				OriginalEstimate  string `json:"originalEstimate"`
-				RemainingEstimate string `json:"remainingEstimate"`
-				TimeSpent         string `json:"timeSpent"`
+				OriginalEstimate         string `json:"originalEstimate"`
+				RemainingEstimate        string `json:"remainingEstimate"`
+				TimeSpent                string `json:"timeSpent"`
+				OriginalEstimateSeconds  int    `json:"originalEstimateSeconds"`
+				RemainingEstimateSeconds int    `json:"remainingEstimateSeconds"`
+				TimeSpentSeconds         int    `json:"timeSpentSeconds"`
 			} `json:"timetracking"`
 		} `json:"fields"`
 	}
@@ -211,9 +228,12 @@ func FetchEnhancedFields(client *jira.Client, baseURL, issueKey, apikey string)
 	if response.Fields.TimeTracking.OriginalEstimate != "" ||
 		response.Fields.TimeTracking.TimeSpent != "" {
 		timeTracking = &TimeTracking{
-			OriginalEstimate:  response.Fields.TimeTracking.OriginalEstimate,
-			RemainingEstimate: response.Fields.TimeTracking.RemainingEstimate,
-			TimeSpent:         response.Fields.TimeTracking.TimeSpent,
+			OriginalEstimate:         response.Fields.TimeTracking.OriginalEstimate,
+			RemainingEstimate:        response.Fields.TimeTracking.RemainingEstimate,
+			TimeSpent:                response.Fields.TimeTracking.TimeSpent,
+			OriginalEstimateSeconds:  response.Fields.TimeTracking.OriginalEstimateSeconds,
+			RemainingEstimateSeconds: response.Fields.TimeTracking.RemainingEstimateSeconds,
+			TimeSpentSeconds:         response.Fields.TimeTracking.TimeSpentSeconds,
 		}
 	}
 
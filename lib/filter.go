@@ -0,0 +1,90 @@
+package lib
+
+import "strings"
+
+// IssueFilter selects issues by release triage fields. Zero values match every issue.
+type IssueFilter struct {
+	FixVersion     string
+	AffectsVersion string
+	MinWatches     int
+	MinVotes       int
+}
+
+// IsEmpty reports whether the filter matches every issue
+func (f IssueFilter) IsEmpty() bool {
+	return f == IssueFilter{}
+}
+
+// Match reports whether the issue satisfies every criterion of the filter
+func (f IssueFilter) Match(issue Issue) bool {
+	if f.FixVersion != "" && !hasVersion(issue.FixVersions, f.FixVersion) {
+		return false
+	}
+	if f.AffectsVersion != "" && !hasVersion(issue.AffectsVersions, f.AffectsVersion) {
+		return false
+	}
+	return issue.Watches >= f.MinWatches && issue.Votes >= f.MinVotes
+}
+
+func hasVersion(versions []Version, name string) bool {
+	for _, v := range versions {
+		if strings.EqualFold(v.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// FilterIssues returns the issues matching the filter
+func FilterIssues(issues []Issue, f IssueFilter) []Issue {
+	var matched []Issue
+	for _, issue := range issues {
+		if f.Match(issue) {
+			matched = append(matched, issue)
+		}
+	}
+	return matched
+}
+
+// ApplyIssueFilter filters the issues of an issue-list result in place and recomputes
+// its counts and estimate totals. Other data types are left unchanged.
+func ApplyIssueFilter(data interface{}, f IssueFilter) {
+	if f.IsEmpty() {
+		return
+	}
+
+	switch v := data.(type) {
+	case []AssignedIssuesResult:
+		for i := range v {
+			v[i].Issues = FilterIssues(v[i].Issues, f)
+			estimates := SumTimeTracking(v[i].Issues)
+			v[i].Estimates = &estimates
+		}
+	case *QueryResult:
+		if v != nil {
+			v.Issues = FilterIssues(v.Issues, f)
+			v.TotalCount = len(v.Issues)
+			estimates := SumTimeTracking(v.Issues)
+			v.Estimates = &estimates
+		}
+	case *UserUpdatesResult:
+		if v != nil {
+			v.Issues = FilterIssues(v.Issues, f)
+			v.TotalCount = len(v.Issues)
+			estimates := SumTimeTracking(v.Issues)
+			v.Estimates = &estimates
+		}
+	case *TeamAssignedIssuesResult:
+		if v != nil {
+			ApplyIssueFilter(v.Members, f)
+			v.Summary = SummarizeAssignedIssues(v.Members)
+		}
+	case *TeamUserUpdatesResult:
+		if v != nil {
+			for i := range v.Members {
+				ApplyIssueFilter(&v.Members[i], f)
+			}
+			v.Summary = SummarizeUserUpdates(v.Members)
+		}
+	}
+}
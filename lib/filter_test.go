@@ -0,0 +1,62 @@
+package lib
+
+import (
+	"testing"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIssueFilter_Match(t *testing.T) {
+	issue := Issue{
+		Key:             "TEST-1",
+		FixVersions:     []Version{{Name: "4.16"}},
+		AffectsVersions: []Version{{Name: "4.15"}},
+		Watches:         5,
+		Votes:           1,
+	}
+
+	assert.True(t, IssueFilter{}.Match(issue))
+	assert.True(t, IssueFilter{FixVersion: "4.16"}.Match(issue))
+	assert.False(t, IssueFilter{FixVersion: "4.17"}.Match(issue))
+	assert.True(t, IssueFilter{AffectsVersion: "4.15"}.Match(issue))
+	assert.False(t, IssueFilter{AffectsVersion: "4.16"}.Match(issue))
+	assert.True(t, IssueFilter{MinWatches: 5}.Match(issue))
+	assert.False(t, IssueFilter{MinWatches: 6}.Match(issue))
+	assert.False(t, IssueFilter{MinVotes: 2}.Match(issue))
+}
+
+func TestApplyIssueFilter(t *testing.T) {
+	result := &QueryResult{
+		TotalCount: 2,
+		Issues: []Issue{
+			{Key: "TEST-1", Votes: 3, TimeTracking: &TimeTracking{OriginalEstimate: "1d"}},
+			{Key: "TEST-2", Votes: 0},
+		},
+	}
+
+	ApplyIssueFilter(result, IssueFilter{MinVotes: 1})
+	assert.Len(t, result.Issues, 1)
+	assert.Equal(t, "TEST-1", result.Issues[0].Key)
+	assert.Equal(t, 1, result.TotalCount)
+	assert.NotNil(t, result.Estimates)
+}
+
+func TestConvertJiraIssue_VersionsWatchesVotes(t *testing.T) {
+	released := true
+	issue := convertJiraIssue(jira.Issue{
+		Key: "TEST-1",
+		Fields: &jira.IssueFields{
+			FixVersions:     []*jira.FixVersion{{ID: "100", Name: "4.16", Released: &released}},
+			AffectsVersions: []*jira.AffectsVersion{{ID: "99", Name: "4.15"}},
+			Watches:         &jira.Watches{WatchCount: 4},
+			Unknowns:        map[string]interface{}{"votes": map[string]interface{}{"votes": float64(2)}},
+		},
+	})
+	assert.Len(t, issue.FixVersions, 1)
+	assert.Equal(t, "4.16", issue.FixVersions[0].Name)
+	assert.True(t, issue.FixVersions[0].Released)
+	assert.Equal(t, "4.15", issue.AffectsVersions[0].Name)
+	assert.Equal(t, 4, issue.Watches)
+	assert.Equal(t, 2, issue.Votes)
+}
@@ -0,0 +1,288 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// histogramBins is the number of bins aimed for when the bin width is chosen automatically
+const histogramBins = 10
+
+// maxHistogramBins caps the number of bins; narrower requested widths are widened to fit
+const maxHistogramBins = 500
+
+// MinFlowBinDays is the narrowest histogram bin width accepted, in days
+const MinFlowBinDays = 0.5
+
+// histogramBarWidth is the width in characters of the longest histogram bar
+const histogramBarWidth = 40
+
+// FlowIssue holds the lead and cycle time of one resolved issue, in days. CycleTimeDays is
+// nil when the issue never entered an in-progress status.
+type FlowIssue struct {
+	Key           string   `json:"key" yaml:"key"`
+	Summary       string   `json:"summary" yaml:"summary"`
+	IssueType     string   `json:"issueType" yaml:"issueType"`
+	Created       string   `json:"created" yaml:"created"`
+	Started       string   `json:"started,omitempty" yaml:"started,omitempty"`
+	Resolved      string   `json:"resolved" yaml:"resolved"`
+	LeadTimeDays  float64  `json:"leadTimeDays" yaml:"leadTimeDays"`
+	CycleTimeDays *float64 `json:"cycleTimeDays,omitempty" yaml:"cycleTimeDays,omitempty"`
+}
+
+// FlowStats summarizes a set of durations in days
+type FlowStats struct {
+	Count  int     `json:"count" yaml:"count"`
+	Mean   float64 `json:"mean" yaml:"mean"`
+	Median float64 `json:"median" yaml:"median"`
+	P85    float64 `json:"p85" yaml:"p85"`
+	P95    float64 `json:"p95" yaml:"p95"`
+	Max    float64 `json:"max" yaml:"max"`
+}
+
+// HistogramBin counts the durations from FromDays (inclusive) to ToDays (exclusive)
+type HistogramBin struct {
+	FromDays float64 `json:"fromDays" yaml:"fromDays"`
+	ToDays   float64 `json:"toDays" yaml:"toDays"`
+	Count    int     `json:"count" yaml:"count"`
+}
+
+// FlowTypeStats holds the lead and cycle time statistics of one issue type
+type FlowTypeStats struct {
+	IssueType string    `json:"issueType" yaml:"issueType"`
+	LeadTime  FlowStats `json:"leadTime" yaml:"leadTime"`
+	CycleTime FlowStats `json:"cycleTime" yaml:"cycleTime"`
+}
+
+// FlowReport holds the lead and cycle times of resolved issues with their statistics,
+// histograms and per-issue-type breakdown. Unresolved counts the issues left out.
+type FlowReport struct {
+	JQL                string          `json:"jql" yaml:"jql"`
+	Unresolved         int             `json:"unresolved" yaml:"unresolved"`
+	LeadTime           FlowStats       `json:"leadTime" yaml:"leadTime"`
+	CycleTime          FlowStats       `json:"cycleTime" yaml:"cycleTime"`
+	LeadTimeHistogram  []HistogramBin  `json:"leadTimeHistogram" yaml:"leadTimeHistogram"`
+	CycleTimeHistogram []HistogramBin  `json:"cycleTimeHistogram" yaml:"cycleTimeHistogram"`
+	ByType             []FlowTypeStats `json:"byType" yaml:"byType"`
+	Issues             []FlowIssue     `json:"issues" yaml:"issues"`
+}
+
+// flowStats computes the statistics of the values
+func flowStats(values []float64) FlowStats {
+	return FlowStats{
+		Count:  len(values),
+		Mean:   mean(values),
+		Median: percentile(values, 50),
+		P85:    percentile(values, 85),
+		P95:    percentile(values, 95),
+		Max:    percentile(values, 100),
+	}
+}
+
+// histogram counts the values in bins of the given width starting at zero. A non-positive
+// width is chosen so that about histogramBins bins cover the values, in whole days. At
+// most maxHistogramBins bins are returned.
+func histogram(values []float64, width float64) []HistogramBin {
+	if len(values) == 0 {
+		return nil
+	}
+	maxValue := percentile(values, 100)
+	if !(width > 0) {
+		width = math.Max(1, math.Ceil(maxValue/histogramBins))
+	}
+	width = math.Max(width, maxValue/(maxHistogramBins-1))
+
+	bins := make([]HistogramBin, int(maxValue/width)+1)
+	for i := range bins {
+		bins[i].FromDays = float64(i) * width
+		bins[i].ToDays = float64(i+1) * width
+	}
+	for _, v := range values {
+		bins[int(v/width)].Count++
+	}
+	return bins
+}
+
+// cycleTimeBounds returns when the issue first entered an in-progress status and when it
+// entered the done status it ended in. ok is false when the issue never started.
+func cycleTimeBounds(issue Issue, resolved time.Time, categories StatusCategories) (started, done time.Time, ok bool) {
+	periods := StatusPeriods(issue, resolved)
+	for _, p := range periods {
+		if categories.Category(p.Status) == CategoryInProgress {
+			started, ok = p.Start, true
+			break
+		}
+	}
+
+	done = resolved
+	for i := len(periods) - 1; i >= 0 && categories.Category(periods[i].Status) == CategoryDone; i-- {
+		done = periods[i].Start
+	}
+	return started, done, ok && done.After(started)
+}
+
+// BuildFlowReport computes the lead time (created to resolved) and cycle time (first entry
+// into an in-progress status to entry into the final done status) of the resolved issues.
+// binDays is the histogram bin width in days; zero chooses it automatically, and widths too
+// narrow for the values are widened to keep at most maxHistogramBins bins.
+func BuildFlowReport(issues []Issue, categories StatusCategories, binDays float64) *FlowReport {
+	report := &FlowReport{}
+	var leadTimes, cycleTimes []float64
+	leadByType := make(map[string][]float64)
+	cycleByType := make(map[string][]float64)
+
+	for _, issue := range issues {
+		resolved, err := parseJiraTime(issue.Resolved)
+		if err != nil {
+			report.Unresolved++
+			continue
+		}
+		created, err := parseJiraTime(issue.Created)
+		if err != nil {
+			continue
+		}
+
+		fi := FlowIssue{
+			Key:          issue.Key,
+			Summary:      issue.Summary,
+			IssueType:    issue.IssueType.Name,
+			Created:      issue.Created,
+			Resolved:     issue.Resolved,
+			LeadTimeDays: resolved.Sub(created).Hours() / 24,
+		}
+		leadTimes = append(leadTimes, fi.LeadTimeDays)
+		leadByType[fi.IssueType] = append(leadByType[fi.IssueType], fi.LeadTimeDays)
+
+		if started, done, ok := cycleTimeBounds(issue, resolved, categories); ok {
+			days := done.Sub(started).Hours() / 24
+			fi.Started = started.Format(time.RFC3339)
+			fi.CycleTimeDays = &days
+			cycleTimes = append(cycleTimes, days)
+			cycleByType[fi.IssueType] = append(cycleByType[fi.IssueType], days)
+		}
+		report.Issues = append(report.Issues, fi)
+	}
+
+	report.LeadTime = flowStats(leadTimes)
+	report.CycleTime = flowStats(cycleTimes)
+	report.LeadTimeHistogram = histogram(leadTimes, binDays)
+	report.CycleTimeHistogram = histogram(cycleTimes, binDays)
+
+	types := make([]string, 0, len(leadByType))
+	for t := range leadByType {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	for _, t := range types {
+		report.ByType = append(report.ByType, FlowTypeStats{
+			IssueType: t,
+			LeadTime:  flowStats(leadByType[t]),
+			CycleTime: flowStats(cycleByType[t]),
+		})
+	}
+	return report
+}
+
+// FetchFlowReport fetches the issues matching the JQL query with their history and computes
+// their lead and cycle times. binDays must be zero or at least MinFlowBinDays. See
+// BuildFlowReport.
+func FetchFlowReport(jiraURL, apikey, jql string, categories StatusCategories, binDays float64, verbose bool) (*FlowReport, error) {
+	if binDays != 0 && !(binDays >= MinFlowBinDays) {
+		return nil, fmt.Errorf("bin width must be 0 (automatic) or at least %g days, got %g", MinFlowBinDays, binDays)
+	}
+	issues, err := FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, err
+	}
+	report := BuildFlowReport(issues, categories, binDays)
+	report.JQL = jql
+	return report, nil
+}
+
+// histogramBar renders a count as an ASCII bar scaled to the largest count
+func histogramBar(count, largest int) string {
+	if largest == 0 {
+		return ""
+	}
+	return strings.Repeat("#", int(math.Round(float64(count)/float64(largest)*histogramBarWidth)))
+}
+
+func printFlowStatsRow(w *tabwriter.Writer, label string, s FlowStats) {
+	fmt.Fprintf(w, "%s\t%d\t%.1f\t%.1f\t%.1f\t%.1f\t%.1f\n", label, s.Count, s.Mean, s.Median, s.P85, s.P95, s.Max)
+}
+
+func printFlowReportTable(w *tabwriter.Writer, report *FlowReport) {
+	fmt.Fprintln(w, "METRIC (DAYS)\tISSUES\tMEAN\tMEDIAN\tP85\tP95\tMAX")
+	printFlowStatsRow(w, "lead time", report.LeadTime)
+	printFlowStatsRow(w, "cycle time", report.CycleTime)
+	for _, t := range report.ByType {
+		printFlowStatsRow(w, t.IssueType+" lead time", t.LeadTime)
+		printFlowStatsRow(w, t.IssueType+" cycle time", t.CycleTime)
+	}
+	if report.Unresolved > 0 {
+		fmt.Fprintf(w, "\n%d unresolved issues were left out\n", report.Unresolved)
+	}
+
+	for _, h := range []struct {
+		title string
+		bins  []HistogramBin
+	}{{"LEAD TIME (DAYS)", report.LeadTimeHistogram}, {"CYCLE TIME (DAYS)", report.CycleTimeHistogram}} {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s\tISSUES\n", h.title)
+		largest := 0
+		for _, b := range h.bins {
+			largest = max(largest, b.Count)
+		}
+		for _, b := range h.bins {
+			fmt.Fprintf(w, "%g-%g\t%d\t%s\n", b.FromDays, b.ToDays, b.Count, histogramBar(b.Count, largest))
+		}
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "KEY\tTYPE\tLEAD TIME\tCYCLE TIME\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%.1f\t%s\t%s\n", issue.Key, issue.IssueType, issue.LeadTimeDays, formatCycleTime(issue.CycleTimeDays), truncateSummary(issue.Summary))
+	}
+}
+
+// formatCycleTime formats an optional cycle time in days
+func formatCycleTime(days *float64) string {
+	if days == nil {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", *days)
+}
+
+func writeFlowReportMarkdown(b *strings.Builder, report *FlowReport) {
+	b.WriteString("# Flow metrics\n\n")
+	b.WriteString("| Metric (days) | Issues | Mean | Median | P85 | P95 | Max |\n")
+	b.WriteString("|---------------|--------|------|--------|-----|-----|-----|\n")
+	row := func(label string, s FlowStats) {
+		fmt.Fprintf(b, "| %s | %d | %.1f | %.1f | %.1f | %.1f | %.1f |\n", escapeMarkdown(label), s.Count, s.Mean, s.Median, s.P85, s.P95, s.Max)
+	}
+	row("Lead time", report.LeadTime)
+	row("Cycle time", report.CycleTime)
+	for _, t := range report.ByType {
+		row(t.IssueType+" lead time", t.LeadTime)
+		row(t.IssueType+" cycle time", t.CycleTime)
+	}
+
+	b.WriteString("\n## Cycle time histogram\n\n")
+	b.WriteString("| Days | Issues |\n")
+	b.WriteString("|------|--------|\n")
+	for _, bin := range report.CycleTimeHistogram {
+		fmt.Fprintf(b, "| %g-%g | %d |\n", bin.FromDays, bin.ToDays, bin.Count)
+	}
+
+	b.WriteString("\n## Issues\n\n")
+	b.WriteString("| Key | Type | Lead time | Cycle time | Summary |\n")
+	b.WriteString("|-----|------|-----------|------------|---------|\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(b, "| %s | %s | %.1f | %s | %s |\n", issue.Key, escapeMarkdown(issue.IssueType),
+			issue.LeadTimeDays, formatCycleTime(issue.CycleTimeDays), escapeMarkdown(issue.Summary))
+	}
+}
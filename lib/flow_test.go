@@ -0,0 +1,89 @@
+package lib
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHistogram(t *testing.T) {
+	assert.Nil(t, histogram(nil, 0))
+
+	bins := histogram([]float64{0.5, 1.5, 1.7, 4}, 1)
+	assert.Len(t, bins, 5)
+	assert.Equal(t, 1, bins[0].Count)
+	assert.Equal(t, 2, bins[1].Count)
+	assert.Equal(t, 1, bins[4].Count)
+	assert.Equal(t, 4.0, bins[4].FromDays)
+
+	// 25 days over about 10 bins gives 3 day bins
+	auto := histogram([]float64{1, 25}, 0)
+	assert.Equal(t, 3.0, auto[0].ToDays)
+	assert.Len(t, auto, 9)
+
+	// a tiny width over a long tail is widened instead of allocating millions of bins
+	capped := histogram([]float64{1, 1000}, 1e-6)
+	assert.LessOrEqual(t, len(capped), maxHistogramBins)
+	assert.Equal(t, 2, capped[0].Count+capped[len(capped)-1].Count)
+}
+
+func TestFetchFlowReport_InvalidBinDays(t *testing.T) {
+	for _, binDays := range []float64{-1, 0.1, math.NaN()} {
+		_, err := FetchFlowReport("http://127.0.0.1:0", "token", "project = CNF", nil, binDays, false)
+		assert.ErrorContains(t, err, "bin width", "binDays %g", binDays)
+	}
+}
+
+func TestBuildFlowReport(t *testing.T) {
+	day := func(d, hour int) time.Time { return time.Date(2024, 1, d, hour, 0, 0, 0, time.UTC) }
+	status := func(at time.Time, from, to string) HistoryItem {
+		return HistoryItem{Created: at, Items: []HistoryChange{{Field: "status", FromString: from, ToString: to}}}
+	}
+	issues := []Issue{
+		{
+			Key: "TEST-1", IssueType: IssueType{Name: "Bug"},
+			Created: "2024-01-01T00:00:00Z", Resolved: "2024-01-11T00:00:00Z",
+			History: []HistoryItem{
+				status(day(3, 0), "New", "Coding"),
+				status(day(5, 0), "Coding", "Review"),
+				// Closing after Done does not move the end of the cycle
+				status(day(9, 0), "Review", "Done"),
+				status(day(11, 0), "Done", "Closed"),
+			},
+		},
+		{
+			// Resolved without ever being worked on
+			Key: "TEST-2", IssueType: IssueType{Name: "Story"},
+			Created: "2024-01-01T00:00:00Z", Resolved: "2024-01-03T00:00:00Z",
+			History: []HistoryItem{status(day(3, 0), "New", "Won't Do")},
+		},
+		{Key: "TEST-3", Created: "2024-01-01T00:00:00Z"},
+	}
+	categories := StatusCategories{"coding": CategoryInProgress, "review": CategoryInProgress, "won't do": CategoryDone}
+
+	report := BuildFlowReport(issues, categories, 0)
+	assert.Equal(t, 1, report.Unresolved)
+	assert.Len(t, report.Issues, 2)
+
+	assert.Equal(t, 10.0, report.Issues[0].LeadTimeDays)
+	assert.Equal(t, 6.0, *report.Issues[0].CycleTimeDays)
+	assert.Equal(t, "2024-01-03T00:00:00Z", report.Issues[0].Started)
+	assert.Nil(t, report.Issues[1].CycleTimeDays)
+
+	assert.Equal(t, 2, report.LeadTime.Count)
+	assert.Equal(t, 6.0, report.LeadTime.Mean)
+	assert.Equal(t, 10.0, report.LeadTime.Max)
+	assert.Equal(t, 1, report.CycleTime.Count)
+
+	assert.Len(t, report.ByType, 2)
+	assert.Equal(t, "Bug", report.ByType[0].IssueType)
+	assert.Equal(t, 1, report.ByType[0].CycleTime.Count)
+	assert.Equal(t, 0, report.ByType[1].CycleTime.Count)
+
+	assert.NotEmpty(t, report.LeadTimeHistogram)
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
@@ -0,0 +1,265 @@
+package lib
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// ForecastConfidences are the confidence levels, in percent, reported by the forecast
+var ForecastConfidences = []int{50, 85, 95}
+
+// maxForecastDays bounds a simulated completion so a run of zero-throughput days cannot
+// loop forever
+const maxForecastDays = 10 * 365
+
+// ForecastDate is the day by which the backlog is done with the given confidence
+type ForecastDate struct {
+	Confidence int    `json:"confidence" yaml:"confidence"`
+	Days       int    `json:"days" yaml:"days"`
+	Date       string `json:"date" yaml:"date"`
+}
+
+// ForecastItems is the number of items done by the target date with the given confidence
+type ForecastItems struct {
+	Confidence int `json:"confidence" yaml:"confidence"`
+	Items      int `json:"items" yaml:"items"`
+}
+
+// ForecastReport holds the outcome of a Monte Carlo forecast. Completion answers when the
+// backlog will be done; ItemsByTarget answers how many items will be done by TargetDate.
+// Seed reproduces the simulation.
+type ForecastReport struct {
+	BacklogJQL    string          `json:"backlogJql" yaml:"backlogJql"`
+	ThroughputJQL string          `json:"throughputJql" yaml:"throughputJql"`
+	Lookback      string          `json:"lookback" yaml:"lookback"`
+	SampleDays    int             `json:"sampleDays" yaml:"sampleDays"`
+	DailyMean     float64         `json:"dailyMean" yaml:"dailyMean"`
+	Backlog       int             `json:"backlog" yaml:"backlog"`
+	Simulations   int             `json:"simulations" yaml:"simulations"`
+	Seed          uint64          `json:"seed" yaml:"seed"`
+	Start         string          `json:"start" yaml:"start"`
+	Completion    []ForecastDate  `json:"completion" yaml:"completion"`
+	TargetDate    string          `json:"targetDate,omitempty" yaml:"targetDate,omitempty"`
+	ItemsByTarget []ForecastItems `json:"itemsByTarget,omitempty" yaml:"itemsByTarget,omitempty"`
+}
+
+// simulateCompletion returns, for each simulation, the number of days needed to finish the
+// backlog when every day resolves a randomly drawn sample of the daily throughput
+func simulateCompletion(samples []int, backlog, simulations int, rng *rand.Rand) []float64 {
+	days := make([]float64, simulations)
+	for i := range days {
+		remaining, d := backlog, 0
+		for remaining > 0 && d < maxForecastDays {
+			remaining -= samples[rng.IntN(len(samples))]
+			d++
+		}
+		days[i] = float64(d)
+	}
+	return days
+}
+
+// simulateItems returns, for each simulation, the number of items resolved over the given
+// number of days
+func simulateItems(samples []int, days, simulations int, rng *rand.Rand) []float64 {
+	items := make([]float64, simulations)
+	for i := range items {
+		total := 0
+		for range days {
+			total += samples[rng.IntN(len(samples))]
+		}
+		items[i] = float64(total)
+	}
+	return items
+}
+
+// BuildForecast runs a Monte Carlo simulation over the daily throughput samples to forecast
+// when a backlog of the given size will be done, with work starting on the day of start.
+// When target is not zero it also forecasts how many items will be done by the end of that day.
+// The same seed always gives the same forecast.
+func BuildForecast(samples []int, backlog, simulations int, seed uint64, start, target time.Time) (*ForecastReport, error) {
+	if simulations <= 0 {
+		return nil, fmt.Errorf("the number of simulations must be positive")
+	}
+	total := 0
+	for _, s := range samples {
+		total += s
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("no issues were resolved in the lookback period")
+	}
+
+	loc := GetTimezone()
+	today := startOfDay(start.In(loc))
+	report := &ForecastReport{
+		SampleDays:  len(samples),
+		DailyMean:   float64(total) / float64(len(samples)),
+		Backlog:     backlog,
+		Simulations: simulations,
+		Seed:        seed,
+		Start:       today.Format("2006-01-02"),
+	}
+	rng := rand.New(rand.NewPCG(seed, seed))
+
+	completion := simulateCompletion(samples, backlog, simulations, rng)
+	for _, c := range ForecastConfidences {
+		days := int(percentile(completion, float64(c)))
+		report.Completion = append(report.Completion, ForecastDate{
+			Confidence: c,
+			Days:       days,
+			// Work finishing on day n ends on the n-th day counting today
+			Date: today.AddDate(0, 0, max(days-1, 0)).Format("2006-01-02"),
+		})
+	}
+
+	if !target.IsZero() {
+		last := startOfDay(target.In(loc))
+		if last.Before(today) {
+			return nil, fmt.Errorf("target date %s is in the past", last.Format("2006-01-02"))
+		}
+		days := 0
+		for d := today; !d.After(last); d = d.AddDate(0, 0, 1) {
+			days++
+		}
+		report.TargetDate = last.Format("2006-01-02")
+		items := simulateItems(samples, days, simulations, rng)
+		for _, c := range ForecastConfidences {
+			// At least this many items are done in c percent of the simulations
+			report.ItemsByTarget = append(report.ItemsByTarget, ForecastItems{
+				Confidence: c,
+				Items:      int(percentile(items, float64(100-c))),
+			})
+		}
+	}
+	return report, nil
+}
+
+// backlogProjectsJQL returns a JQL clause selecting the projects of the issues, or "" when
+// there are none
+func backlogProjectsJQL(issues []Issue) string {
+	seen := map[string]bool{}
+	var keys []string
+	for _, issue := range issues {
+		if key := issue.Project.Key; key != "" && !seen[key] {
+			seen[key] = true
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return ""
+	}
+	sort.Strings(keys)
+	return fmt.Sprintf("project in (%s)", strings.Join(keys, ", "))
+}
+
+// FetchForecast counts the unresolved issues matching backlogJQL and forecasts when they will
+// be done from the daily throughput of the issues matching throughputJQL resolved between
+// lookback and yesterday. An empty throughputJQL selects the projects of the backlog issues.
+// target is an optional date for the items-by-date forecast. Both dates accept any form
+// understood by ParseDateRange. See BuildForecast.
+func FetchForecast(jiraURL, apikey, backlogJQL, throughputJQL, lookback, target string, simulations int, seed uint64) (*ForecastReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if backlogJQL == "" {
+		return nil, fmt.Errorf("JQL query must not be empty")
+	}
+
+	now := time.Now()
+	history, err := ParseDateRange(lookback, "yesterday", now)
+	if err != nil {
+		return nil, fmt.Errorf("invalid lookback: %w", err)
+	}
+	var targetDay time.Time
+	if target != "" {
+		r, err := ParseDateRange("today", target, now)
+		if err != nil {
+			return nil, fmt.Errorf("invalid target date: %w", err)
+		}
+		targetDay = r.End.Add(-time.Nanosecond)
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+	backlog, err := searchAllIssues(client, backlogJQL)
+	if err != nil {
+		return nil, err
+	}
+	remaining := 0
+	for _, issue := range backlog {
+		if issue.Resolved == "" {
+			remaining++
+		}
+	}
+	if throughputJQL == "" {
+		throughputJQL = backlogProjectsJQL(backlog)
+		if throughputJQL == "" {
+			return nil, fmt.Errorf("the backlog has no issues to take the throughput projects from; give a throughput query")
+		}
+	}
+
+	resolved, err := searchAllIssues(client, fmt.Sprintf("(%s) AND %s", throughputJQL, history.JQL("resolved")))
+	if err != nil {
+		return nil, err
+	}
+	throughput, err := BuildThroughputReport(resolved, IntervalDay, &history)
+	if err != nil {
+		return nil, err
+	}
+	samples := make([]int, len(throughput.Intervals))
+	for i, interval := range throughput.Intervals {
+		samples[i] = interval.Count
+	}
+
+	report, err := BuildForecast(samples, remaining, simulations, seed, now, targetDay)
+	if err != nil {
+		return nil, err
+	}
+	report.BacklogJQL = backlogJQL
+	report.ThroughputJQL = throughputJQL
+	report.Lookback = history.String()
+	return report, nil
+}
+
+func printForecastTable(w *tabwriter.Writer, report *ForecastReport) {
+	fmt.Fprintf(w, "Backlog:\t%d issues\n", report.Backlog)
+	fmt.Fprintf(w, "Throughput:\t%.2f issues per day over %d days (%s)\n", report.DailyMean, report.SampleDays, report.Lookback)
+	fmt.Fprintf(w, "Simulations:\t%d (seed %d)\n", report.Simulations, report.Seed)
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "CONFIDENCE\tDONE BY\tDAYS")
+	for _, c := range report.Completion {
+		fmt.Fprintf(w, "%d%%\t%s\t%d\n", c.Confidence, c.Date, c.Days)
+	}
+	if report.TargetDate != "" {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "CONFIDENCE\tITEMS BY %s\n", report.TargetDate)
+		for _, i := range report.ItemsByTarget {
+			fmt.Fprintf(w, "%d%%\t%d\n", i.Confidence, i.Items)
+		}
+	}
+}
+
+func writeForecastMarkdown(b *strings.Builder, report *ForecastReport) {
+	b.WriteString("# Forecast\n\n")
+	fmt.Fprintf(b, "- Backlog: %d issues\n", report.Backlog)
+	fmt.Fprintf(b, "- Throughput: %.2f issues per day over %d days (%s)\n", report.DailyMean, report.SampleDays, report.Lookback)
+	fmt.Fprintf(b, "- Simulations: %d (seed %d)\n\n", report.Simulations, report.Seed)
+	b.WriteString("| Confidence | Done by | Days |\n")
+	b.WriteString("|------------|---------|------|\n")
+	for _, c := range report.Completion {
+		fmt.Fprintf(b, "| %d%% | %s | %d |\n", c.Confidence, c.Date, c.Days)
+	}
+	if report.TargetDate != "" {
+		fmt.Fprintf(b, "\n## Items done by %s\n\n", report.TargetDate)
+		b.WriteString("| Confidence | Items |\n")
+		b.WriteString("|------------|-------|\n")
+		for _, i := range report.ItemsByTarget {
+			fmt.Fprintf(b, "| %d%% | %d |\n", i.Confidence, i.Items)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildForecast(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	start := time.Date(2024, 1, 1, 15, 0, 0, 0, time.UTC)
+
+	// A steady two issues a day finishes ten issues on the fifth day whatever the draws
+	steady, err := BuildForecast([]int{2, 2, 2}, 10, 100, 1, start, time.Time{})
+	assert.NoError(t, err)
+	assert.Equal(t, 2.0, steady.DailyMean)
+	for _, c := range steady.Completion {
+		assert.Equal(t, 5, c.Days)
+		assert.Equal(t, "2024-01-05", c.Date)
+	}
+	assert.Empty(t, steady.ItemsByTarget)
+
+	target := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	samples := []int{0, 1, 3, 0, 2, 5, 1}
+	report, err := BuildForecast(samples, 20, 2000, 42, start, target)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{50, 85, 95}, []int{report.Completion[0].Confidence, report.Completion[1].Confidence, report.Completion[2].Confidence})
+	// Higher confidence takes longer and promises fewer items
+	assert.LessOrEqual(t, report.Completion[0].Days, report.Completion[1].Days)
+	assert.LessOrEqual(t, report.Completion[1].Days, report.Completion[2].Days)
+	assert.Equal(t, "2024-01-10", report.TargetDate)
+	assert.GreaterOrEqual(t, report.ItemsByTarget[0].Items, report.ItemsByTarget[2].Items)
+
+	// The same seed gives the same forecast
+	again, err := BuildForecast(samples, 20, 2000, 42, start, target)
+	assert.NoError(t, err)
+	assert.Equal(t, report, again)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+}
+
+func TestBuildForecast_Errors(t *testing.T) {
+	now := time.Now()
+	_, err := BuildForecast([]int{0, 0}, 10, 100, 1, now, time.Time{})
+	assert.Error(t, err)
+	_, err = BuildForecast([]int{1}, 10, 0, 1, now, time.Time{})
+	assert.Error(t, err)
+	_, err = BuildForecast([]int{1}, 10, 100, 1, now, now.AddDate(0, 0, -3))
+	assert.Error(t, err)
+}
+
+func TestFetchForecast_Validation(t *testing.T) {
+	_, err := FetchForecast("", "", "project = TEST", "project = TEST", "-90d", "", 100, 1)
+	assert.Error(t, err)
+	_, err = FetchForecast("https://jira.example.com", "token", "", "project = TEST", "-90d", "", 100, 1)
+	assert.Error(t, err)
+}
+
+func TestFetchForecast_DefaultThroughput(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	yesterday := time.Now().UTC().AddDate(0, 0, -1).Format("2006-01-02") + "T12:00:00.000+0000"
+	var throughputJQL string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		var issues []map[string]interface{}
+		if strings.Contains(jql, "resolved >=") {
+			throughputJQL = jql
+			issues = []map[string]interface{}{{"key": "CNF-1", "fields": map[string]interface{}{"resolutiondate": yesterday}}}
+		} else {
+			issues = []map[string]interface{}{
+				{"key": "OTHER-2", "fields": map[string]interface{}{"project": map[string]string{"id": "2", "key": "OTHER"}}},
+				{"key": "CNF-3", "fields": map[string]interface{}{"project": map[string]string{"id": "1", "key": "CNF"}}},
+				{"key": "CNF-4", "fields": map[string]interface{}{"project": map[string]string{"id": "1", "key": "CNF"}}},
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 0, "maxResults": 100, "total": len(issues), "issues": issues,
+		})
+	}))
+	defer server.Close()
+
+	report, err := FetchForecast(server.URL, "token", "fixVersion = 4.16", "", "-7d", "", 100, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Backlog)
+	assert.Equal(t, "project in (CNF, OTHER)", report.ThroughputJQL)
+	assert.True(t, strings.HasPrefix(throughputJQL, "(project in (CNF, OTHER)) AND "), throughputJQL)
+}
@@ -22,6 +22,7 @@ type JiraConfig struct {
 type User struct {
 	Key          string `json:"key" yaml:"key"`
 	Name         string `json:"name" yaml:"name"`
+	AccountID    string `json:"accountId,omitempty" yaml:"accountId,omitempty"`
 	EmailAddress string `json:"emailAddress" yaml:"emailAddress"`
 	DisplayName  string `json:"displayName" yaml:"displayName"`
 	Active       bool   `json:"active" yaml:"active"`
@@ -52,21 +53,58 @@ type Project struct {
 	Name string `json:"name" yaml:"name"`
 }
 
+// Version represents a JIRA project version (fix or affects version)
+type Version struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Released    bool   `json:"released" yaml:"released"`
+	Archived    bool   `json:"archived" yaml:"archived"`
+	ReleaseDate string `json:"releaseDate,omitempty" yaml:"releaseDate,omitempty"`
+}
+
 // Comment represents a Jira issue comment
 type Comment struct {
-	ID      string    `json:"id" yaml:"id"`
-	Author  string    `json:"author" yaml:"author"`
-	Body    string    `json:"body" yaml:"body"`
-	Created time.Time `json:"created" yaml:"created"`
-	Updated time.Time `json:"updated" yaml:"updated"`
+	ID         string    `json:"id" yaml:"id"`
+	Author     string    `json:"author" yaml:"author"`
+	AuthorName string    `json:"authorName,omitempty" yaml:"authorName,omitempty"`
+	Body       string    `json:"body" yaml:"body"`
+	Created    time.Time `json:"created" yaml:"created"`
+	Updated    time.Time `json:"updated" yaml:"updated"`
+}
+
+// Worklog represents a single work log entry on a Jira issue
+type Worklog struct {
+	ID               string    `json:"id" yaml:"id"`
+	IssueKey         string    `json:"issueKey" yaml:"issueKey"`
+	Author           *User     `json:"author" yaml:"author"`
+	Comment          string    `json:"comment,omitempty" yaml:"comment,omitempty"`
+	Started          time.Time `json:"started" yaml:"started"`
+	TimeSpent        string    `json:"timeSpent" yaml:"timeSpent"`
+	TimeSpentSeconds int       `json:"timeSpentSeconds" yaml:"timeSpentSeconds"`
+}
+
+// Attachment represents a file attached to a Jira issue
+type Attachment struct {
+	ID       string `json:"id" yaml:"id"`
+	Filename string `json:"filename" yaml:"filename"`
+	Size     int64  `json:"size" yaml:"size"`
+	MimeType string `json:"mimeType" yaml:"mimeType"`
+	Author   *User  `json:"author" yaml:"author"`
+	Created  string `json:"created" yaml:"created"`
+	Content  string `json:"content" yaml:"content"`
+
+	// Populated when the attachment has been downloaded
+	Path   string `json:"path,omitempty" yaml:"path,omitempty"`
+	SHA256 string `json:"sha256,omitempty" yaml:"sha256,omitempty"`
 }
 
 // HistoryItem represents a Jira issue history entry
 type HistoryItem struct {
-	ID      string          `json:"id" yaml:"id"`
-	Author  string          `json:"author" yaml:"author"`
-	Created time.Time       `json:"created" yaml:"created"`
-	Items   []HistoryChange `json:"items" yaml:"items"`
+	ID         string          `json:"id" yaml:"id"`
+	Author     string          `json:"author" yaml:"author"`
+	AuthorName string          `json:"authorName,omitempty" yaml:"authorName,omitempty"`
+	Created    time.Time       `json:"created" yaml:"created"`
+	Items      []HistoryChange `json:"items" yaml:"items"`
 }
 
 // HistoryChange represents a specific field change in history
@@ -79,9 +117,12 @@ type HistoryChange struct {
 
 // TimeTracking represents time tracking information
 type TimeTracking struct {
-	OriginalEstimate  string `json:"originalEstimate,omitempty" yaml:"originalEstimate,omitempty"`
-	RemainingEstimate string `json:"remainingEstimate,omitempty" yaml:"remainingEstimate,omitempty"`
-	TimeSpent         string `json:"timeSpent,omitempty" yaml:"timeSpent,omitempty"`
+	OriginalEstimate         string `json:"originalEstimate,omitempty" yaml:"originalEstimate,omitempty"`
+	RemainingEstimate        string `json:"remainingEstimate,omitempty" yaml:"remainingEstimate,omitempty"`
+	TimeSpent                string `json:"timeSpent,omitempty" yaml:"timeSpent,omitempty"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds,omitempty" yaml:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds,omitempty" yaml:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds,omitempty" yaml:"timeSpentSeconds,omitempty"`
 }
 
 // IssuePermissions represents what the authenticated user can access
@@ -115,6 +156,13 @@ type Issue struct {
 	Created     string    `json:"created" yaml:"created"`
 	Updated     string    `json:"updated" yaml:"updated"`
 	Resolved    string    `json:"resolved" yaml:"resolved"`
+	StoryPoints float64   `json:"storyPoints,omitempty" yaml:"storyPoints,omitempty"`
+	Epic        string    `json:"epic,omitempty" yaml:"epic,omitempty"`
+
+	FixVersions     []Version `json:"fixVersions,omitempty" yaml:"fixVersions,omitempty"`
+	AffectsVersions []Version `json:"affectsVersions,omitempty" yaml:"affectsVersions,omitempty"`
+	Watches         int       `json:"watches" yaml:"watches"`
+	Votes           int       `json:"votes" yaml:"votes"`
 
 	// Enhanced context fields (populated on demand)
 	Comments     []Comment     `json:"comments,omitempty" yaml:"comments,omitempty"`
@@ -122,20 +170,23 @@ type Issue struct {
 	Labels       []string      `json:"labels,omitempty" yaml:"labels,omitempty"`
 	Components   []string      `json:"components,omitempty" yaml:"components,omitempty"`
 	TimeTracking *TimeTracking `json:"timeTracking,omitempty" yaml:"timeTracking,omitempty"`
+	Attachments  []Attachment  `json:"attachments,omitempty" yaml:"attachments,omitempty"`
 }
 
 // AssignedIssuesResult represents the result of fetching assigned issues
 type AssignedIssuesResult struct {
-	User   string  `json:"user" yaml:"user"`
-	Issues []Issue `json:"issues" yaml:"issues"`
+	User      string          `json:"user" yaml:"user"`
+	Issues    []Issue         `json:"issues" yaml:"issues"`
+	Estimates *EstimateTotals `json:"estimates,omitempty" yaml:"estimates,omitempty"`
 }
 
 // UserUpdatesResult represents the result of fetching user updates in a date range
 type UserUpdatesResult struct {
-	User       string  `json:"user" yaml:"user"`
-	DateRange  string  `json:"dateRange" yaml:"dateRange"`
-	TotalCount int     `json:"totalCount" yaml:"totalCount"`
-	Issues     []Issue `json:"issues" yaml:"issues"`
+	User       string          `json:"user" yaml:"user"`
+	DateRange  string          `json:"dateRange" yaml:"dateRange"`
+	TotalCount int             `json:"totalCount" yaml:"totalCount"`
+	Issues     []Issue         `json:"issues" yaml:"issues"`
+	Estimates  *EstimateTotals `json:"estimates,omitempty" yaml:"estimates,omitempty"`
 }
 
 // convertJiraUser converts a JIRA user to our User struct
@@ -146,12 +197,40 @@ func convertJiraUser(jiraUser *jira.User) *User {
 	return &User{
 		Key:          jiraUser.Key,
 		Name:         jiraUser.Name,
+		AccountID:    jiraUser.AccountID,
 		EmailAddress: jiraUser.EmailAddress,
 		DisplayName:  jiraUser.DisplayName,
 		Active:       jiraUser.Active,
 	}
 }
 
+// newVersion builds a Version from the fields shared by go-jira's FixVersion and AffectsVersion
+func newVersion(id, name string, released, archived *bool, releaseDate string) Version {
+	return Version{
+		ID:          id,
+		Name:        name,
+		Released:    released != nil && *released,
+		Archived:    archived != nil && *archived,
+		ReleaseDate: releaseDate,
+	}
+}
+
+// convertJiraAttachment converts a JIRA attachment to our Attachment struct
+func convertJiraAttachment(a *jira.Attachment) Attachment {
+	attachment := Attachment{
+		ID:       a.ID,
+		Filename: a.Filename,
+		Size:     int64(a.Size),
+		MimeType: a.MimeType,
+		Author:   convertJiraUser(a.Author),
+		Content:  a.Content,
+	}
+	if created, err := parseJiraTime(a.Created); err == nil {
+		attachment.Created = created.Format(time.RFC3339)
+	}
+	return attachment
+}
+
 // convertJiraIssue converts a JIRA issue to our Issue struct
 func convertJiraIssue(jiraIssue jira.Issue) Issue {
 	issue := Issue{
@@ -203,9 +282,106 @@ func convertJiraIssue(jiraIssue jira.Issue) Issue {
 		issue.Resolved = time.Time(jiraIssue.Fields.Resolutiondate).Format(time.RFC3339)
 	}
 
+	// Handle time tracking. Search results only carry the seconds fields, while
+	// single-issue responses also include the formatted strings.
+	if tt := jiraIssue.Fields.TimeTracking; tt != nil {
+		issue.TimeTracking = &TimeTracking{
+			OriginalEstimate:         tt.OriginalEstimate,
+			RemainingEstimate:        tt.RemainingEstimate,
+			TimeSpent:                tt.TimeSpent,
+			OriginalEstimateSeconds:  tt.OriginalEstimateSeconds,
+			RemainingEstimateSeconds: tt.RemainingEstimateSeconds,
+			TimeSpentSeconds:         tt.TimeSpentSeconds,
+		}
+	} else if f := jiraIssue.Fields; f.TimeOriginalEstimate > 0 || f.TimeEstimate > 0 || f.TimeSpent > 0 {
+		issue.TimeTracking = &TimeTracking{
+			OriginalEstimateSeconds:  f.TimeOriginalEstimate,
+			RemainingEstimateSeconds: f.TimeEstimate,
+			TimeSpentSeconds:         f.TimeSpent,
+		}
+		if f.TimeOriginalEstimate > 0 {
+			issue.TimeTracking.OriginalEstimate = FormatJiraDuration(time.Duration(f.TimeOriginalEstimate) * time.Second)
+		}
+		if f.TimeEstimate > 0 {
+			issue.TimeTracking.RemainingEstimate = FormatJiraDuration(time.Duration(f.TimeEstimate) * time.Second)
+		}
+		if f.TimeSpent > 0 {
+			issue.TimeTracking.TimeSpent = FormatJiraDuration(time.Duration(f.TimeSpent) * time.Second)
+		}
+	}
+
+	// Handle fix and affects versions
+	for _, v := range jiraIssue.Fields.FixVersions {
+		if v != nil {
+			issue.FixVersions = append(issue.FixVersions, newVersion(v.ID, v.Name, v.Released, v.Archived, v.ReleaseDate))
+		}
+	}
+	for _, v := range jiraIssue.Fields.AffectsVersions {
+		if v != nil {
+			issue.AffectsVersions = append(issue.AffectsVersions, newVersion(v.ID, v.Name, v.Released, v.Archived, v.ReleaseDate))
+		}
+	}
+
+	// Handle watchers and votes. go-jira has no typed votes field, so it is read from Unknowns.
+	if jiraIssue.Fields.Watches != nil {
+		issue.Watches = jiraIssue.Fields.Watches.WatchCount
+	}
+	if votes, ok := jiraIssue.Fields.Unknowns["votes"].(map[string]interface{}); ok {
+		if n, ok := votes["votes"].(float64); ok {
+			issue.Votes = int(n)
+		}
+	}
+
+	// Handle attachments
+	for _, a := range jiraIssue.Fields.Attachments {
+		if a != nil {
+			issue.Attachments = append(issue.Attachments, convertJiraAttachment(a))
+		}
+	}
+
+	// Handle story points, which live in an instance-specific custom field
+	if points, ok := jiraIssue.Fields.Unknowns[GetStoryPointsField()].(float64); ok {
+		issue.StoryPoints = points
+	}
+
+	// Handle the epic link, which also lives in an instance-specific custom field
+	if epic, ok := jiraIssue.Fields.Unknowns[GetEpicLinkField()].(string); ok {
+		issue.Epic = epic
+	}
+
 	return issue
 }
 
+// DefaultStoryPointsField is the custom field holding story points on issues.redhat.com
+const DefaultStoryPointsField = "customfield_12310243"
+
+var storyPointsField = DefaultStoryPointsField
+
+// SetStoryPointsField sets the custom field ID used to read story points
+func SetStoryPointsField(fieldID string) {
+	storyPointsField = fieldID
+}
+
+// GetStoryPointsField returns the custom field ID used to read story points
+func GetStoryPointsField() string {
+	return storyPointsField
+}
+
+// DefaultEpicLinkField is the custom field holding the epic link on issues.redhat.com
+const DefaultEpicLinkField = "customfield_12311140"
+
+var epicLinkField = DefaultEpicLinkField
+
+// SetEpicLinkField sets the custom field ID used to read the epic an issue belongs to
+func SetEpicLinkField(fieldID string) {
+	epicLinkField = fieldID
+}
+
+// GetEpicLinkField returns the custom field ID used to read the epic an issue belongs to
+func GetEpicLinkField() string {
+	return epicLinkField
+}
+
 // NewJiraClient creates a new Jira client with bearer token authentication.
 func NewJiraClient(jiraURL, apikey string) (*jira.Client, error) {
 	tokenAuth := jira.BearerAuthTransport{
@@ -238,19 +414,35 @@ func FetchAssignedIssuesWithProject(jiraURL, jiraUser, apikey string, projectID
 		for _, issue := range issues {
 			convertedIssues = append(convertedIssues, convertJiraIssue(issue))
 		}
+		estimates := SumTimeTracking(convertedIssues)
 		allResults = append(allResults, AssignedIssuesResult{
-			User:   user,
-			Issues: convertedIssues,
+			User:      user,
+			Issues:    convertedIssues,
+			Estimates: &estimates,
 		})
 	}
 	return allResults, nil
 }
 
+// searchAllIssues runs a JQL query and follows pagination until every matching issue is returned
+func searchAllIssues(client *jira.Client, jql string) ([]Issue, error) {
+	var issues []Issue
+	err := client.Issue.SearchPages(jql, &jira.SearchOptions{MaxResults: 100}, func(issue jira.Issue) error {
+		issues = append(issues, convertJiraIssue(issue))
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("executing JQL query: %w", err)
+	}
+	return issues, nil
+}
+
 // QueryResult represents the result of a custom JQL query
 type QueryResult struct {
-	JQL        string  `json:"jql" yaml:"jql"`
-	TotalCount int     `json:"totalCount" yaml:"totalCount"`
-	Issues     []Issue `json:"issues" yaml:"issues"`
+	JQL        string          `json:"jql" yaml:"jql"`
+	TotalCount int             `json:"totalCount" yaml:"totalCount"`
+	Issues     []Issue         `json:"issues" yaml:"issues"`
+	Estimates  *EstimateTotals `json:"estimates,omitempty" yaml:"estimates,omitempty"`
 }
 
 // FetchIssuesWithJQL runs an arbitrary JQL query and returns matching issues.
@@ -284,28 +476,33 @@ func FetchIssuesWithJQL(jiraURL, apikey, jql string, maxResults int) (*QueryResu
 		convertedIssues = append(convertedIssues, convertJiraIssue(issue))
 	}
 
+	estimates := SumTimeTracking(convertedIssues)
 	return &QueryResult{
 		JQL:        jql,
 		TotalCount: len(convertedIssues),
 		Issues:     convertedIssues,
+		Estimates:  &estimates,
 	}, nil
 }
 
-// FetchUserIssuesInDateRange fetches issues assigned to a user that were updated within a specific date range
-// startDate and endDate should be in YYYY-MM-DD format
+// FetchUserIssuesInDateRange fetches issues assigned to a user that were updated within a specific date range.
+// startDate and endDate accept any form understood by ParseDateRange, such as YYYY-MM-DD, -7d or last-week;
+// the end date is inclusive.
 func FetchUserIssuesInDateRange(jiraURL, jiraUser, apikey string, assignee string, startDate, endDate string) (*UserUpdatesResult, error) {
 	if jiraURL == "" || jiraUser == "" || assignee == "" || startDate == "" || endDate == "" {
 		return nil, fmt.Errorf("jiraURL, jiraUser, assignee, startDate, and endDate must be provided")
 	}
 
-	// Validate date format
-	if _, err := time.Parse("2006-01-02", startDate); err != nil {
-		return nil, fmt.Errorf("invalid start date format (use YYYY-MM-DD): %w", err)
-	}
-	if _, err := time.Parse("2006-01-02", endDate); err != nil {
-		return nil, fmt.Errorf("invalid end date format (use YYYY-MM-DD): %w", err)
+	dateRange, err := ParseDateRange(startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
 	}
+	return fetchUserIssuesInRange(jiraURL, apikey, assignee, dateRange)
+}
 
+// fetchUserIssuesInRange fetches the issues assigned to a user that were updated within an
+// already parsed date range
+func fetchUserIssuesInRange(jiraURL, apikey, assignee string, dateRange DateRange) (*UserUpdatesResult, error) {
 	client, err := NewJiraClient(jiraURL, apikey)
 	if err != nil {
 		return nil, fmt.Errorf("creating Jira client: %w", err)
@@ -313,26 +510,20 @@ func FetchUserIssuesInDateRange(jiraURL, jiraUser, apikey string, assignee strin
 
 	// JQL query to find issues assigned to the user that were updated in the date range
 	// Include all issues regardless of status (including resolved/closed)
-	jql := fmt.Sprintf("assignee=\"%s\" AND updated >= \"%s\" AND updated <= \"%s\" AND (resolution is empty OR resolution is not empty) ORDER BY updated DESC", assignee, startDate, endDate)
+	jql := fmt.Sprintf("assignee=\"%s\" AND %s AND (resolution is empty OR resolution is not empty) ORDER BY updated DESC", assignee, dateRange.JQL("updated"))
 
-	issues, resp, err := client.Issue.Search(jql, nil)
+	convertedIssues, err := searchAllIssues(client, jql)
 	if err != nil {
 		return nil, fmt.Errorf("fetching issues for %s: %w", assignee, err)
 	}
-	if resp != nil && resp.StatusCode != 200 {
-		return nil, fmt.Errorf("jira API error for %s: %s", assignee, resp.Status)
-	}
-
-	var convertedIssues []Issue
-	for _, issue := range issues {
-		convertedIssues = append(convertedIssues, convertJiraIssue(issue))
-	}
 
+	estimates := SumTimeTracking(convertedIssues)
 	return &UserUpdatesResult{
 		User:       assignee,
-		DateRange:  fmt.Sprintf("%s to %s", startDate, endDate),
+		DateRange:  dateRange.String(),
 		TotalCount: len(convertedIssues),
 		Issues:     convertedIssues,
+		Estimates:  &estimates,
 	}, nil
 }
 
@@ -355,29 +546,101 @@ func PrintYAML(data interface{}) error {
 }
 
 // PrintTable prints issues in a human-readable table format using tabwriter.
-// Accepts []AssignedIssuesResult, *UserUpdatesResult, or *QueryResult.
+// Accepts []AssignedIssuesResult, *UserUpdatesResult, *QueryResult, *TeamAssignedIssuesResult,
+// *TeamUserUpdatesResult, *SprintReport,
+// *VelocityReport, *TimesheetReport, *AttachmentsResult, *ProjectVersionsResult,
+// *ProjectListResult, *ProjectInfo, *UserSearchResult, *ActivityReport, *StandupReport,
+// *TimeInStatusReport, *FlowReport, *ThroughputReport, *CFDReport, *AgingReport,
+// *ForecastReport, *BurndownReport, *SLAReport, *WorkloadReport, *ChurnReport, or *StaleReport.
 func PrintTable(data interface{}) error {
 	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "KEY\tSTATUS\tPRIORITY\tSUMMARY")
 
 	switch v := data.(type) {
-	case []AssignedIssuesResult:
-		for _, r := range v {
-			for _, issue := range r.Issues {
-				printIssueRow(w, issue)
-			}
+	case []AssignedIssuesResult, *QueryResult, *UserUpdatesResult, *TeamAssignedIssuesResult, *TeamUserUpdatesResult:
+		printIssueListTable(w, v, DefaultIssueColumns)
+	case *SprintReport:
+		if v != nil {
+			printSprintReportTable(w, v)
 		}
-	case *QueryResult:
+	case *VelocityReport:
 		if v != nil {
-			for _, issue := range v.Issues {
-				printIssueRow(w, issue)
-			}
+			printVelocityReportTable(w, v)
 		}
-	case *UserUpdatesResult:
+	case *TimesheetReport:
 		if v != nil {
-			for _, issue := range v.Issues {
-				printIssueRow(w, issue)
-			}
+			printTimesheetTable(w, v)
+		}
+	case *AttachmentsResult:
+		if v != nil {
+			printAttachmentsTable(w, v)
+		}
+	case *ProjectVersionsResult:
+		if v != nil {
+			printProjectVersionsTable(w, v)
+		}
+	case *ProjectListResult:
+		if v != nil {
+			printProjectListTable(w, v)
+		}
+	case *ProjectInfo:
+		if v != nil {
+			printProjectInfoTable(w, v)
+		}
+	case *UserSearchResult:
+		if v != nil {
+			printUserSearchTable(w, v)
+		}
+	case *ActivityReport:
+		if v != nil {
+			printActivityTable(w, v)
+		}
+	case *StandupReport:
+		if v != nil {
+			printStandupTable(w, v)
+		}
+	case *TimeInStatusReport:
+		if v != nil {
+			printTimeInStatusTable(w, v)
+		}
+	case *FlowReport:
+		if v != nil {
+			printFlowReportTable(w, v)
+		}
+	case *ThroughputReport:
+		if v != nil {
+			printThroughputTable(w, v)
+		}
+	case *CFDReport:
+		if v != nil {
+			printCFDTable(w, v)
+		}
+	case *AgingReport:
+		if v != nil {
+			printAgingTable(w, v)
+		}
+	case *ForecastReport:
+		if v != nil {
+			printForecastTable(w, v)
+		}
+	case *BurndownReport:
+		if v != nil {
+			printBurndownTable(w, v)
+		}
+	case *SLAReport:
+		if v != nil {
+			printSLATable(w, v)
+		}
+	case *WorkloadReport:
+		if v != nil {
+			printWorkloadTable(w, v)
+		}
+	case *ChurnReport:
+		if v != nil {
+			printChurnTable(w, v)
+		}
+	case *StaleReport:
+		if v != nil {
+			printStaleTable(w, v)
 		}
 	default:
 		return fmt.Errorf("unsupported data type for table output: %T", data)
@@ -386,17 +649,12 @@ func PrintTable(data interface{}) error {
 	return w.Flush()
 }
 
-func printIssueRow(w *tabwriter.Writer, issue Issue) {
-	summary := issue.Summary
+// truncateSummary shortens summaries so table rows stay on one line
+func truncateSummary(summary string) string {
 	if len(summary) > 60 {
-		summary = summary[:57] + "..."
-	}
-	fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
-		issue.Key,
-		issue.Status.Name,
-		issue.Priority.Name,
-		summary,
-	)
+		return summary[:57] + "..."
+	}
+	return summary
 }
 
 // FetchUserIssuesInDateRangeWithContext - Enhanced version of FetchUserIssuesInDateRange
@@ -99,10 +99,11 @@ func TestPrintTable_AssignedIssuesResult(t *testing.T) {
 					Priority: Priority{Name: "Major"},
 				},
 				{
-					Key:      "CNF-1235",
-					Summary:  "Update SDK version",
-					Status:   Status{Name: "In Progress"},
-					Priority: Priority{Name: "Critical"},
+					Key:          "CNF-1235",
+					Summary:      "Update SDK version",
+					Status:       Status{Name: "In Progress"},
+					Priority:     Priority{Name: "Critical"},
+					TimeTracking: &TimeTracking{OriginalEstimate: "1d", TimeSpent: "4h"},
 				},
 			},
 		},
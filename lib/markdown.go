@@ -0,0 +1,180 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// PrintMarkdown prints data as GitHub-flavored Markdown to stdout.
+// Accepts []AssignedIssuesResult, *UserUpdatesResult, *QueryResult, *TeamAssignedIssuesResult,
+// *TeamUserUpdatesResult, *ActivityReport, *StandupReport, *WeeklyReport, *SprintReport,
+// *VelocityReport, *TimeInStatusReport, *FlowReport, *ThroughputReport, *CFDReport,
+// *AgingReport, *ForecastReport, *SLAReport, *WorkloadReport, *ChurnReport, or *StaleReport.
+func PrintMarkdown(data interface{}) error {
+	var b strings.Builder
+
+	switch v := data.(type) {
+	case []AssignedIssuesResult:
+		for _, r := range v {
+			fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(r.User))
+			writeIssueMarkdownTable(&b, r.Issues)
+			b.WriteString("\n")
+		}
+	case *QueryResult:
+		if v != nil {
+			writeIssueMarkdownTable(&b, v.Issues)
+		}
+	case *UserUpdatesResult:
+		if v != nil {
+			fmt.Fprintf(&b, "## %s (%s)\n\n", escapeMarkdown(v.User), v.DateRange)
+			writeIssueMarkdownTable(&b, v.Issues)
+		}
+	case *TeamAssignedIssuesResult:
+		if v != nil {
+			for _, r := range v.Members {
+				fmt.Fprintf(&b, "## %s\n\n", escapeMarkdown(r.User))
+				writeIssueMarkdownTable(&b, r.Issues)
+				b.WriteString("\n")
+			}
+			writeTeamSummaryMarkdown(&b, v.Summary)
+		}
+	case *TeamUserUpdatesResult:
+		if v != nil {
+			for _, r := range v.Members {
+				fmt.Fprintf(&b, "## %s (%s)\n\n", escapeMarkdown(r.User), r.DateRange)
+				writeIssueMarkdownTable(&b, r.Issues)
+				b.WriteString("\n")
+			}
+			writeTeamSummaryMarkdown(&b, v.Summary)
+		}
+	case *ActivityReport:
+		if v != nil {
+			writeActivityMarkdown(&b, v)
+		}
+	case *StandupReport:
+		if v != nil {
+			writeStandupMarkdown(&b, v)
+		}
+	case *WeeklyReport:
+		if v != nil {
+			if err := RenderWeeklyReport(&b, v, "markdown", ""); err != nil {
+				return err
+			}
+		}
+	case *SprintReport:
+		if v != nil {
+			writeSprintReportMarkdown(&b, v)
+		}
+	case *VelocityReport:
+		if v != nil {
+			writeVelocityReportMarkdown(&b, v)
+		}
+	case *TimeInStatusReport:
+		if v != nil {
+			writeTimeInStatusMarkdown(&b, v)
+		}
+	case *FlowReport:
+		if v != nil {
+			writeFlowReportMarkdown(&b, v)
+		}
+	case *ThroughputReport:
+		if v != nil {
+			writeThroughputMarkdown(&b, v)
+		}
+	case *CFDReport:
+		if v != nil {
+			writeCFDMarkdown(&b, v)
+		}
+	case *AgingReport:
+		if v != nil {
+			writeAgingMarkdown(&b, v)
+		}
+	case *ForecastReport:
+		if v != nil {
+			writeForecastMarkdown(&b, v)
+		}
+	case *SLAReport:
+		if v != nil {
+			writeSLAMarkdown(&b, v)
+		}
+	case *WorkloadReport:
+		if v != nil {
+			writeWorkloadMarkdown(&b, v)
+		}
+	case *ChurnReport:
+		if v != nil {
+			writeChurnMarkdown(&b, v)
+		}
+	case *StaleReport:
+		if v != nil {
+			writeStaleMarkdown(&b, v)
+		}
+	default:
+		return fmt.Errorf("unsupported data type for markdown output: %T", data)
+	}
+
+	fmt.Print(b.String())
+	return nil
+}
+
+// escapeMarkdown escapes characters that would break a Markdown table cell
+func escapeMarkdown(s string) string {
+	s = strings.ReplaceAll(s, "|", "\\|")
+	return strings.ReplaceAll(s, "\n", " ")
+}
+
+func writeIssueMarkdownTable(b *strings.Builder, issues []Issue) {
+	b.WriteString("| Key | Status | Priority | Summary |\n")
+	b.WriteString("|-----|--------|----------|---------|\n")
+	for _, issue := range issues {
+		fmt.Fprintf(b, "| %s | %s | %s | %s |\n",
+			issue.Key,
+			escapeMarkdown(issue.Status.Name),
+			escapeMarkdown(issue.Priority.Name),
+			escapeMarkdown(issue.Summary),
+		)
+	}
+}
+
+func writeSprintReportMarkdown(b *strings.Builder, report *SprintReport) {
+	fmt.Fprintf(b, "# Sprint report: %s\n\n", escapeMarkdown(report.Sprint.Name))
+	if report.Sprint.StartDate != nil {
+		fmt.Fprintf(b, "- Start: %s\n", report.Sprint.StartDate.Format("2006-01-02"))
+	}
+	if end := report.Sprint.CompleteDate; end != nil {
+		fmt.Fprintf(b, "- Completed: %s\n", end.Format("2006-01-02"))
+	} else if end := report.Sprint.EndDate; end != nil {
+		fmt.Fprintf(b, "- End: %s\n", end.Format("2006-01-02"))
+	}
+	b.WriteString("\n## Totals\n\n")
+	b.WriteString("| | Issues | Points |\n")
+	b.WriteString("|---|---|---|\n")
+	for _, row := range sprintReportTotalRows(report.Totals) {
+		fmt.Fprintf(b, "| %s | %d | %g |\n", row.label, row.count.Issues, row.count.Points)
+	}
+	b.WriteString("\n## Issues\n\n")
+	b.WriteString("| Key | Scope | Outcome | Points | Status | Summary |\n")
+	b.WriteString("|-----|-------|---------|--------|--------|---------|\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(b, "| %s | %s | %s | %g | %s | %s |\n",
+			issue.Key,
+			issue.Scope,
+			issue.Outcome,
+			issue.Points,
+			escapeMarkdown(issue.Status),
+			escapeMarkdown(issue.Summary),
+		)
+	}
+}
+
+func writeVelocityReportMarkdown(b *strings.Builder, report *VelocityReport) {
+	fmt.Fprintf(b, "# Velocity: board %d\n\n", report.BoardID)
+	b.WriteString("| Sprint | Committed | Completed | Rolling avg |\n")
+	b.WriteString("|--------|-----------|-----------|-------------|\n")
+	for _, s := range report.Sprints {
+		fmt.Fprintf(b, "| %s | %g | %g | %.1f |\n", escapeMarkdown(s.Sprint.Name), s.Committed, s.Completed, s.RollingAverage)
+	}
+	fmt.Fprintf(b, "\n- Average committed: %.1f\n", report.AverageCommitted)
+	fmt.Fprintf(b, "- Average completed: %.1f\n", report.AverageCompleted)
+	fmt.Fprintf(b, "- Standard deviation: %.1f\n", report.StdDevCompleted)
+}
@@ -0,0 +1,33 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrintMarkdown_AssignedIssuesResult(t *testing.T) {
+	data := []AssignedIssuesResult{
+		{
+			User: "testuser",
+			Issues: []Issue{
+				{Key: "CNF-1234", Summary: "Fix | pipe", Status: Status{Name: "Open"}, Priority: Priority{Name: "Major"}},
+			},
+		},
+	}
+	assert.NoError(t, PrintMarkdown(data))
+}
+
+func TestPrintMarkdown_QueryResult(t *testing.T) {
+	assert.NoError(t, PrintMarkdown(&QueryResult{JQL: "project = CNF"}))
+}
+
+func TestPrintMarkdown_UnsupportedType(t *testing.T) {
+	err := PrintMarkdown("unsupported")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "unsupported data type")
+}
+
+func TestEscapeMarkdown(t *testing.T) {
+	assert.Equal(t, "a \\| b c", escapeMarkdown("a | b\nc"))
+}
@@ -0,0 +1,234 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// ProjectsCacheName is the cache entry holding the project list
+const ProjectsCacheName = "projects"
+
+// ProjectComponent represents a component of a project and its lead
+type ProjectComponent struct {
+	ID          string `json:"id" yaml:"id"`
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Lead        *User  `json:"lead,omitempty" yaml:"lead,omitempty"`
+}
+
+// WorkflowStatus represents a workflow status and its status category
+type WorkflowStatus struct {
+	ID       string `json:"id" yaml:"id"`
+	Name     string `json:"name" yaml:"name"`
+	Category string `json:"category" yaml:"category"`
+}
+
+// IssueTypeStatuses lists the workflow statuses available to an issue type
+type IssueTypeStatuses struct {
+	IssueType IssueType        `json:"issueType" yaml:"issueType"`
+	Subtask   bool             `json:"subtask" yaml:"subtask"`
+	Statuses  []WorkflowStatus `json:"statuses" yaml:"statuses"`
+}
+
+// ProjectInfo describes the metadata of a project: its components, versions, issue
+// types and the workflow statuses of each issue type
+type ProjectInfo struct {
+	Project    Project             `json:"project" yaml:"project"`
+	Lead       *User               `json:"lead,omitempty" yaml:"lead,omitempty"`
+	Components []ProjectComponent  `json:"components" yaml:"components"`
+	Versions   []Version           `json:"versions" yaml:"versions"`
+	IssueTypes []IssueTypeStatuses `json:"issueTypes" yaml:"issueTypes"`
+}
+
+// ProjectListResult lists the projects visible to the user
+type ProjectListResult struct {
+	Projects []Project `json:"projects" yaml:"projects"`
+}
+
+// jiraUserJSON is the user representation returned by the Jira REST API
+type jiraUserJSON struct {
+	Key          string `json:"key"`
+	Name         string `json:"name"`
+	AccountID    string `json:"accountId"`
+	EmailAddress string `json:"emailAddress"`
+	DisplayName  string `json:"displayName"`
+	Active       bool   `json:"active"`
+}
+
+func (u *jiraUserJSON) toUser() *User {
+	if u == nil {
+		return nil
+	}
+	return &User{
+		Key:          u.Key,
+		Name:         u.Name,
+		AccountID:    u.AccountID,
+		EmailAddress: u.EmailAddress,
+		DisplayName:  u.DisplayName,
+		Active:       u.Active,
+	}
+}
+
+// ProjectCacheName returns the cache entry holding the metadata of a project
+func ProjectCacheName(projectKey string) string {
+	return "project-" + strings.ToUpper(projectKey)
+}
+
+// Statuses returns the distinct workflow status names of the project in workflow order
+func (p *ProjectInfo) Statuses() []string {
+	seen := make(map[string]bool)
+	var names []string
+	for _, t := range p.IssueTypes {
+		for _, s := range t.Statuses {
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				names = append(names, s.Name)
+			}
+		}
+	}
+	return names
+}
+
+// FetchProjects retrieves the projects visible to the user
+func FetchProjects(jiraURL, apikey string) (*ProjectListResult, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+
+	var projects []Project
+	if err := getJSON(apikey, jiraURL+"/rest/api/2/project", &projects); err != nil {
+		return nil, fmt.Errorf("fetching projects: %w", err)
+	}
+	return &ProjectListResult{Projects: projects}, nil
+}
+
+// FetchProjectInfo retrieves the components, versions, issue types and workflow statuses of a project
+func FetchProjectInfo(jiraURL, apikey, projectKey string) (*ProjectInfo, error) {
+	if jiraURL == "" || apikey == "" || projectKey == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, and projectKey must be provided")
+	}
+
+	var project struct {
+		ID       string        `json:"id"`
+		Key      string        `json:"key"`
+		Name     string        `json:"name"`
+		Lead     *jiraUserJSON `json:"lead"`
+		Versions []struct {
+			ID          string `json:"id"`
+			Name        string `json:"name"`
+			Released    bool   `json:"released"`
+			Archived    bool   `json:"archived"`
+			ReleaseDate string `json:"releaseDate"`
+		} `json:"versions"`
+	}
+	if err := getJSON(apikey, fmt.Sprintf("%s/rest/api/2/project/%s", jiraURL, projectKey), &project); err != nil {
+		return nil, fmt.Errorf("fetching project %s: %w", projectKey, err)
+	}
+
+	info := &ProjectInfo{
+		Project: Project{ID: project.ID, Key: project.Key, Name: project.Name},
+		Lead:    project.Lead.toUser(),
+	}
+	for _, v := range project.Versions {
+		info.Versions = append(info.Versions, Version{
+			ID:          v.ID,
+			Name:        v.Name,
+			Released:    v.Released,
+			Archived:    v.Archived,
+			ReleaseDate: v.ReleaseDate,
+		})
+	}
+
+	rl := GetGlobalRateLimiter()
+	rl.Wait()
+
+	// The project resource omits component leads, so components are fetched separately
+	var components []struct {
+		ID          string        `json:"id"`
+		Name        string        `json:"name"`
+		Description string        `json:"description"`
+		Lead        *jiraUserJSON `json:"lead"`
+	}
+	if err := getJSON(apikey, fmt.Sprintf("%s/rest/api/2/project/%s/components", jiraURL, projectKey), &components); err != nil {
+		return nil, fmt.Errorf("fetching components for %s: %w", projectKey, err)
+	}
+	for _, c := range components {
+		info.Components = append(info.Components, ProjectComponent{
+			ID:          c.ID,
+			Name:        c.Name,
+			Description: c.Description,
+			Lead:        c.Lead.toUser(),
+		})
+	}
+
+	rl.Wait()
+
+	var issueTypes []struct {
+		ID       string `json:"id"`
+		Name     string `json:"name"`
+		Subtask  bool   `json:"subtask"`
+		Statuses []struct {
+			ID             string `json:"id"`
+			Name           string `json:"name"`
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"statuses"`
+	}
+	if err := getJSON(apikey, fmt.Sprintf("%s/rest/api/2/project/%s/statuses", jiraURL, projectKey), &issueTypes); err != nil {
+		return nil, fmt.Errorf("fetching statuses for %s: %w", projectKey, err)
+	}
+	for _, t := range issueTypes {
+		its := IssueTypeStatuses{
+			IssueType: IssueType{ID: t.ID, Name: t.Name},
+			Subtask:   t.Subtask,
+		}
+		for _, s := range t.Statuses {
+			its.Statuses = append(its.Statuses, WorkflowStatus{ID: s.ID, Name: s.Name, Category: s.StatusCategory.Key})
+		}
+		info.IssueTypes = append(info.IssueTypes, its)
+	}
+
+	return info, nil
+}
+
+func printProjectListTable(w *tabwriter.Writer, result *ProjectListResult) {
+	fmt.Fprintln(w, "KEY\tNAME")
+	for _, p := range result.Projects {
+		fmt.Fprintf(w, "%s\t%s\n", p.Key, p.Name)
+	}
+}
+
+func printProjectInfoTable(w *tabwriter.Writer, info *ProjectInfo) {
+	fmt.Fprintf(w, "Project:\t%s (%s)\n", info.Project.Name, info.Project.Key)
+	if info.Lead != nil {
+		fmt.Fprintf(w, "Lead:\t%s\n", info.Lead.DisplayName)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "COMPONENT\tLEAD")
+	for _, c := range info.Components {
+		lead := ""
+		if c.Lead != nil {
+			lead = c.Lead.DisplayName
+		}
+		fmt.Fprintf(w, "%s\t%s\n", c.Name, lead)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "VERSION\tRELEASED\tRELEASE DATE")
+	for _, v := range info.Versions {
+		fmt.Fprintf(w, "%s\t%t\t%s\n", v.Name, v.Released, v.ReleaseDate)
+	}
+
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "ISSUE TYPE\tSTATUSES")
+	for _, t := range info.IssueTypes {
+		names := make([]string, 0, len(t.Statuses))
+		for _, s := range t.Statuses {
+			names = append(names, s.Name)
+		}
+		fmt.Fprintf(w, "%s\t%s\n", t.IssueType.Name, strings.Join(names, ", "))
+	}
+}
@@ -0,0 +1,84 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchProjectInfo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/project/TEST":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"id":   "10000",
+				"key":  "TEST",
+				"name": "Test Project",
+				"lead": map[string]string{"name": "lead", "displayName": "Project Lead"},
+				"versions": []map[string]interface{}{
+					{"id": "100", "name": "1.0", "released": true},
+				},
+			})
+		case "/rest/api/2/project/TEST/components":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "1", "name": "api", "lead": map[string]string{"displayName": "API Lead"}},
+				{"id": "2", "name": "docs"},
+			})
+		case "/rest/api/2/project/TEST/statuses":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "1", "name": "Bug", "statuses": []map[string]interface{}{
+					{"id": "10", "name": "To Do", "statusCategory": map[string]string{"key": "new"}},
+					{"id": "11", "name": "Done", "statusCategory": map[string]string{"key": "done"}},
+				}},
+				{"id": "2", "name": "Story", "statuses": []map[string]interface{}{
+					{"id": "10", "name": "To Do", "statusCategory": map[string]string{"key": "new"}},
+					{"id": "12", "name": "In Progress", "statusCategory": map[string]string{"key": "indeterminate"}},
+				}},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	info, err := FetchProjectInfo(server.URL, "test-token", "TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST", info.Project.Key)
+	assert.Equal(t, "Project Lead", info.Lead.DisplayName)
+	assert.Len(t, info.Versions, 1)
+	assert.Len(t, info.Components, 2)
+	assert.Equal(t, "API Lead", info.Components[0].Lead.DisplayName)
+	assert.Nil(t, info.Components[1].Lead)
+	assert.Len(t, info.IssueTypes, 2)
+	assert.Equal(t, "done", info.IssueTypes[0].Statuses[1].Category)
+	assert.Equal(t, []string{"To Do", "Done", "In Progress"}, info.Statuses())
+
+	assert.NoError(t, PrintTable(info))
+}
+
+func TestFetchProjects(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/project", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]string{
+			{"id": "1", "key": "CNF", "name": "Cloud Native Functions"},
+			{"id": "2", "key": "TEST", "name": "Test Project"},
+		})
+	}))
+	defer server.Close()
+
+	result, err := FetchProjects(server.URL, "test-token")
+	assert.NoError(t, err)
+	assert.Len(t, result.Projects, 2)
+	assert.Equal(t, "CNF", result.Projects[0].Key)
+}
+
+func TestFetchProjectInfo_EmptyConfig(t *testing.T) {
+	info, err := FetchProjectInfo("", "", "TEST")
+	assert.Error(t, err)
+	assert.Nil(t, info)
+}
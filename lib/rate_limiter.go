@@ -16,6 +16,7 @@ type RateLimiter struct {
 	backoffMultiple float64
 	enabled         bool
 	mu              sync.RWMutex
+	waitMu          sync.Mutex
 }
 
 // DefaultRateLimiter returns a rate limiter with sensible defaults
@@ -41,14 +42,19 @@ func NewRateLimiter(delay time.Duration, maxRetries int) *RateLimiter {
 	}
 }
 
-// Wait applies the rate limiting delay
+// Wait applies the rate limiting delay. Callers take turns, so concurrent requests are
+// spaced at least the delay apart instead of sleeping side by side.
 func (rl *RateLimiter) Wait() {
 	rl.mu.RLock()
-	defer rl.mu.RUnlock()
+	enabled, delay := rl.enabled, rl.delay
+	rl.mu.RUnlock()
 
-	if rl.enabled && rl.delay > 0 {
-		time.Sleep(rl.delay)
+	if !enabled || delay <= 0 {
+		return
 	}
+	rl.waitMu.Lock()
+	defer rl.waitMu.Unlock()
+	time.Sleep(delay)
 }
 
 // SetDelay updates the delay duration
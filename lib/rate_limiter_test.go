@@ -3,6 +3,7 @@ package lib
 import (
 	"net/http"
 	"net/http/httptest"
+	"sync"
 	"testing"
 	"time"
 
@@ -64,6 +65,23 @@ func TestRateLimiterWait(t *testing.T) {
 	assert.Less(t, elapsed, 10*time.Millisecond) // Should be nearly instant
 }
 
+// TestRateLimiterWait_Concurrent tests that concurrent callers are spaced out
+func TestRateLimiterWait_Concurrent(t *testing.T) {
+	rl := NewRateLimiter(30*time.Millisecond, 3)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rl.Wait()
+		}()
+	}
+	wg.Wait()
+	assert.GreaterOrEqual(t, time.Since(start), 90*time.Millisecond)
+}
+
 // TestRateLimiterDoRequestSuccess tests successful request without retry
 func TestRateLimiterDoRequestSuccess(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
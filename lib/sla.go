@@ -0,0 +1,283 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// SLA target states
+const (
+	SLAMet      = "met"
+	SLARunning  = "running"
+	SLAAtRisk   = "at-risk"
+	SLABreached = "breached"
+)
+
+// DefaultSLAAtRisk is the share of a target that may elapse before an open target is at risk
+const DefaultSLAAtRisk = 0.75
+
+// SLARule sets the first response and resolution targets of the issues it matches. An empty
+// Priority or IssueType matches any value; a zero target is not tracked. Targets are elapsed
+// calendar time, or business hours when BusinessHours is set.
+type SLARule struct {
+	Name          string        `json:"name" yaml:"name"`
+	Priority      string        `json:"priority,omitempty" yaml:"priority,omitempty"`
+	IssueType     string        `json:"issueType,omitempty" yaml:"issueType,omitempty"`
+	FirstResponse time.Duration `json:"firstResponse,omitempty" yaml:"firstResponse,omitempty"`
+	Resolution    time.Duration `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+	BusinessHours bool          `json:"businessHours,omitempty" yaml:"businessHours,omitempty"`
+}
+
+// Matches reports whether the rule applies to the issue
+func (r SLARule) Matches(issue Issue) bool {
+	return (r.Priority == "" || strings.EqualFold(r.Priority, issue.Priority.Name)) &&
+		(r.IssueType == "" || strings.EqualFold(r.IssueType, issue.IssueType.Name))
+}
+
+// MatchSLARule returns the first rule that applies to the issue, or nil
+func MatchSLARule(rules []SLARule, issue Issue) *SLARule {
+	for i := range rules {
+		if rules[i].Matches(issue) {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+// SLATarget is the progress of an issue against one target. At is when the target was
+// reached; it is empty while the target is open.
+type SLATarget struct {
+	State        string  `json:"state" yaml:"state"`
+	TargetHours  float64 `json:"targetHours" yaml:"targetHours"`
+	ElapsedHours float64 `json:"elapsedHours" yaml:"elapsedHours"`
+	At           string  `json:"at,omitempty" yaml:"at,omitempty"`
+}
+
+// SLAIssue is an issue checked against the rule it matched
+type SLAIssue struct {
+	Key           string     `json:"key" yaml:"key"`
+	Summary       string     `json:"summary" yaml:"summary"`
+	Priority      string     `json:"priority" yaml:"priority"`
+	IssueType     string     `json:"issueType" yaml:"issueType"`
+	Assignee      string     `json:"assignee" yaml:"assignee"`
+	Rule          string     `json:"rule" yaml:"rule"`
+	BusinessHours bool       `json:"businessHours,omitempty" yaml:"businessHours,omitempty"`
+	FirstResponse *SLATarget `json:"firstResponse,omitempty" yaml:"firstResponse,omitempty"`
+	Resolution    *SLATarget `json:"resolution,omitempty" yaml:"resolution,omitempty"`
+}
+
+// state returns the worst state of the issue's targets
+func (i SLAIssue) state() string {
+	worst := SLAMet
+	for _, t := range []*SLATarget{i.FirstResponse, i.Resolution} {
+		if t != nil && slaStateRank[t.State] > slaStateRank[worst] {
+			worst = t.State
+		}
+	}
+	return worst
+}
+
+// slaStateRank orders the target states from best to worst
+var slaStateRank = map[string]int{SLAMet: 0, SLARunning: 1, SLAAtRisk: 2, SLABreached: 3}
+
+// SLAReport lists the issues that breached an SLA target or are at risk of breaching one.
+// Checked counts the issues matched by a rule and Unmatched those matched by none.
+type SLAReport struct {
+	JQL         string     `json:"jql" yaml:"jql"`
+	AtRiskShare float64    `json:"atRiskShare" yaml:"atRiskShare"`
+	Checked     int        `json:"checked" yaml:"checked"`
+	Unmatched   int        `json:"unmatched" yaml:"unmatched"`
+	Breached    int        `json:"breached" yaml:"breached"`
+	AtRisk      int        `json:"atRisk" yaml:"atRisk"`
+	Issues      []SLAIssue `json:"issues" yaml:"issues"`
+}
+
+// firstResponse returns when someone other than the reporter first commented on the issue
+func firstResponse(issue Issue) (time.Time, bool) {
+	var first time.Time
+	for _, c := range issue.Comments {
+		if userMatches(issue.Reporter, c.AuthorName) || userMatches(issue.Reporter, c.Author) {
+			continue
+		}
+		if first.IsZero() || c.Created.Before(first) {
+			first = c.Created
+		}
+	}
+	return first, !first.IsZero()
+}
+
+// checkSLATarget measures the time from start until the target was reached at end, or until
+// now when it is still open, and classifies it against the target
+func checkSLATarget(target time.Duration, start, end time.Time, reached bool, now time.Time, atRisk float64, measure func(start, end time.Time) time.Duration) *SLATarget {
+	if target <= 0 {
+		return nil
+	}
+	if !reached {
+		end = now
+	}
+	elapsed := measure(start, end)
+
+	t := &SLATarget{TargetHours: target.Hours(), ElapsedHours: elapsed.Hours()}
+	switch {
+	case elapsed > target:
+		t.State = SLABreached
+	case reached:
+		t.State = SLAMet
+	case float64(elapsed) >= atRisk*float64(target):
+		t.State = SLAAtRisk
+	default:
+		t.State = SLARunning
+	}
+	if reached {
+		t.At = end.Format(time.RFC3339)
+	}
+	return t
+}
+
+// BuildSLAReport checks each issue against the first rule it matches. The first response is
+// the first comment by someone other than the reporter, so the issues need their comments;
+// an issue resolved without a response stops the response clock. Open targets are at risk
+// once the atRisk share of the target has elapsed. Business hours start at workdayStart.
+func BuildSLAReport(issues []Issue, rules []SLARule, atRisk float64, workdayStart time.Duration, now time.Time) *SLAReport {
+	report := &SLAReport{AtRiskShare: atRisk, Issues: []SLAIssue{}}
+	for _, issue := range issues {
+		rule := MatchSLARule(rules, issue)
+		created, err := parseJiraTime(issue.Created)
+		if rule == nil || err != nil {
+			report.Unmatched++
+			continue
+		}
+		report.Checked++
+
+		measure := func(start, end time.Time) time.Duration { return end.Sub(start) }
+		if rule.BusinessHours {
+			measure = func(start, end time.Time) time.Duration { return BusinessDuration(start, end, workdayStart) }
+		}
+
+		resolved, resolvedErr := parseJiraTime(issue.Resolved)
+		isResolved := resolvedErr == nil
+		responded, isResponded := firstResponse(issue)
+		if !isResponded && isResolved {
+			responded, isResponded = resolved, true
+		}
+
+		si := SLAIssue{
+			Key:           issue.Key,
+			Summary:       issue.Summary,
+			Priority:      issue.Priority.Name,
+			IssueType:     issue.IssueType.Name,
+			Assignee:      userDisplayName(issue.Assignee),
+			Rule:          rule.Name,
+			BusinessHours: rule.BusinessHours,
+			FirstResponse: checkSLATarget(rule.FirstResponse, created, responded, isResponded, now, atRisk, measure),
+			Resolution:    checkSLATarget(rule.Resolution, created, resolved, isResolved, now, atRisk, measure),
+		}
+		switch si.state() {
+		case SLABreached:
+			report.Breached++
+		case SLAAtRisk:
+			report.AtRisk++
+		default:
+			continue
+		}
+		report.Issues = append(report.Issues, si)
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		return slaStateRank[report.Issues[i].state()] > slaStateRank[report.Issues[j].state()]
+	})
+	return report
+}
+
+// FetchSLAReport fetches the issues matching the JQL query, with the comments of those whose
+// rule has a first response target, and checks them against the rules. See BuildSLAReport.
+func FetchSLAReport(jiraURL, apikey, jql string, rules []SLARule, atRisk float64, workdayStart time.Duration, verbose bool) (*SLAReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if jql == "" {
+		return nil, fmt.Errorf("JQL query must not be empty")
+	}
+	if len(rules) == 0 {
+		return nil, fmt.Errorf("no SLA rules are configured")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+	issues, err := searchAllIssues(client, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := GetGlobalRateLimiter()
+	for i := range issues {
+		rule := MatchSLARule(rules, issues[i])
+		if rule == nil || rule.FirstResponse <= 0 {
+			continue
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching comments for %s (%d/%d)...\n", issues[i].Key, i+1, len(issues))
+		}
+		rl.Wait()
+		comments, err := FetchIssueComments(client, jiraURL, issues[i].Key, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching comments for %s: %w", issues[i].Key, err)
+		}
+		issues[i].Comments = comments
+	}
+
+	report := BuildSLAReport(issues, rules, atRisk, workdayStart, time.Now())
+	report.JQL = jql
+	return report, nil
+}
+
+// formatSLATarget formats the state and elapsed time of a target against its length, in
+// working days for business hours and 24 hour days otherwise
+func (i SLAIssue) formatSLATarget(t *SLATarget) string {
+	if t == nil {
+		return "-"
+	}
+	format := formatCalendarDuration
+	if i.BusinessHours {
+		format = FormatJiraDuration
+	}
+	elapsed := time.Duration(t.ElapsedHours * float64(time.Hour))
+	target := time.Duration(t.TargetHours * float64(time.Hour))
+	return fmt.Sprintf("%s (%s / %s)", t.State, format(elapsed), format(target))
+}
+
+func printSLATable(w *tabwriter.Writer, report *SLAReport) {
+	fmt.Fprintln(w, "KEY\tPRIORITY\tTYPE\tRULE\tFIRST RESPONSE\tRESOLUTION\tASSIGNEE\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n", issue.Key, issue.Priority, issue.IssueType, issue.Rule,
+			issue.formatSLATarget(issue.FirstResponse), issue.formatSLATarget(issue.Resolution), issue.Assignee, truncateSummary(issue.Summary))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Checked:\t%d\n", report.Checked)
+	fmt.Fprintf(w, "Breached:\t%d\n", report.Breached)
+	fmt.Fprintf(w, "At risk:\t%d\n", report.AtRisk)
+	if report.Unmatched > 0 {
+		fmt.Fprintf(w, "No rule:\t%d\n", report.Unmatched)
+	}
+}
+
+func writeSLAMarkdown(b *strings.Builder, report *SLAReport) {
+	b.WriteString("# SLA report\n\n")
+	fmt.Fprintf(b, "%d issues checked: %d breached, %d at risk.\n\n", report.Checked, report.Breached, report.AtRisk)
+	if len(report.Issues) == 0 {
+		return
+	}
+	b.WriteString("| Key | Priority | Type | Rule | First response | Resolution | Assignee | Summary |\n")
+	b.WriteString("|-----|----------|------|------|----------------|------------|----------|---------|\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s | %s | %s | %s |\n", issue.Key, escapeMarkdown(issue.Priority),
+			escapeMarkdown(issue.IssueType), escapeMarkdown(issue.Rule), issue.formatSLATarget(issue.FirstResponse),
+			issue.formatSLATarget(issue.Resolution), escapeMarkdown(issue.Assignee), escapeMarkdown(issue.Summary))
+	}
+}
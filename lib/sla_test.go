@@ -0,0 +1,114 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchSLARule(t *testing.T) {
+	rules := []SLARule{
+		{Name: "critical-bugs", Priority: "Critical", IssueType: "Bug"},
+		{Name: "critical", Priority: "critical"},
+	}
+	bug := Issue{Priority: Priority{Name: "Critical"}, IssueType: IssueType{Name: "Bug"}}
+	story := Issue{Priority: Priority{Name: "Critical"}, IssueType: IssueType{Name: "Story"}}
+	minor := Issue{Priority: Priority{Name: "Minor"}, IssueType: IssueType{Name: "Bug"}}
+
+	assert.Equal(t, "critical-bugs", MatchSLARule(rules, bug).Name)
+	assert.Equal(t, "critical", MatchSLARule(rules, story).Name)
+	assert.Nil(t, MatchSLARule(rules, minor))
+}
+
+func TestBuildSLAReport(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	day := func(d int) time.Time { return time.Date(2024, 1, d, 0, 0, 0, 0, time.UTC) }
+	reporter := &User{Name: "rita", DisplayName: "Rita"}
+	critical := func(key string) Issue {
+		return Issue{
+			Key: key, Created: "2024-01-01T00:00:00Z", Reporter: reporter,
+			Priority: Priority{Name: "Critical"}, IssueType: IssueType{Name: "Bug"},
+		}
+	}
+	major := func(key, created string) Issue {
+		return Issue{Key: key, Created: created, Priority: Priority{Name: "Major"}, IssueType: IssueType{Name: "Bug"}}
+	}
+
+	// Responded to in 2 hours, the reporter's own comment does not count; resolved in 2 days
+	met := critical("SLA-1")
+	met.Resolved = "2024-01-03T00:00:00Z"
+	met.Comments = []Comment{
+		{Author: "rita", Created: day(1).Add(time.Hour)},
+		{Author: "bob", Created: day(1).Add(2 * time.Hour)},
+	}
+	// Responded to in 6 hours and still open after 9 days
+	breached := critical("SLA-2")
+	breached.Comments = []Comment{{Author: "bob", Created: day(1).Add(6 * time.Hour)}}
+	// Resolved without a response, which stops the response clock
+	resolvedQuietly := critical("SLA-7")
+	resolvedQuietly.Resolved = "2024-01-01T03:00:00Z"
+	// Open for 12 of 16 business hours: Monday afternoon and Tuesday
+	task := Issue{Key: "SLA-6", Created: "2024-01-08T13:00:00Z", Priority: Priority{Name: "Major"}, IssueType: IssueType{Name: "Task"}}
+
+	issues := []Issue{
+		met,
+		breached,
+		major("SLA-3", "2024-01-06T00:00:00Z"), // 96 of 120 hours
+		major("SLA-4", "2024-01-09T00:00:00Z"), // 24 of 120 hours
+		{Key: "SLA-5", Created: "2024-01-01T00:00:00Z", Priority: Priority{Name: "Minor"}, IssueType: IssueType{Name: "Story"}},
+		task,
+		resolvedQuietly,
+	}
+	rules := []SLARule{
+		{Name: "tasks", IssueType: "Task", Resolution: 16 * time.Hour, BusinessHours: true},
+		{Name: "critical", Priority: "Critical", FirstResponse: 4 * time.Hour, Resolution: 120 * time.Hour},
+		{Name: "major", Priority: "Major", Resolution: 120 * time.Hour},
+	}
+
+	report := BuildSLAReport(issues, rules, DefaultSLAAtRisk, DefaultWorkdayStart, day(10))
+	assert.Equal(t, 6, report.Checked)
+	assert.Equal(t, 1, report.Unmatched)
+	assert.Equal(t, 1, report.Breached)
+	assert.Equal(t, 2, report.AtRisk)
+
+	assert.Len(t, report.Issues, 3)
+	first := report.Issues[0]
+	assert.Equal(t, "SLA-2", first.Key)
+	assert.Equal(t, "critical", first.Rule)
+	assert.Equal(t, "Unassigned", first.Assignee)
+	assert.Equal(t, SLABreached, first.FirstResponse.State)
+	assert.Equal(t, 6.0, first.FirstResponse.ElapsedHours)
+	assert.Equal(t, "2024-01-01T06:00:00Z", first.FirstResponse.At)
+	assert.Equal(t, SLABreached, first.Resolution.State)
+	assert.Empty(t, first.Resolution.At)
+
+	second := report.Issues[1]
+	assert.Equal(t, "SLA-3", second.Key)
+	assert.Nil(t, second.FirstResponse)
+	assert.Equal(t, SLAAtRisk, second.Resolution.State)
+	assert.Equal(t, 96.0, second.Resolution.ElapsedHours)
+
+	third := report.Issues[2]
+	assert.Equal(t, "SLA-6", third.Key)
+	assert.True(t, third.BusinessHours)
+	assert.Equal(t, SLAAtRisk, third.Resolution.State)
+	assert.Equal(t, 12.0, third.Resolution.ElapsedHours)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
+
+func TestFetchSLAReport_Validation(t *testing.T) {
+	rules := []SLARule{{Name: "all", Resolution: time.Hour}}
+
+	_, err := FetchSLAReport("", "", "project = TEST", rules, DefaultSLAAtRisk, DefaultWorkdayStart, false)
+	assert.Error(t, err)
+	_, err = FetchSLAReport("https://jira.example.com", "token", "", rules, DefaultSLAAtRisk, DefaultWorkdayStart, false)
+	assert.Error(t, err)
+	_, err = FetchSLAReport("https://jira.example.com", "token", "project = TEST", nil, DefaultSLAAtRisk, DefaultWorkdayStart, false)
+	assert.Error(t, err)
+}
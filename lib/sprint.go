@@ -0,0 +1,140 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Sprint represents a Jira agile sprint
+type Sprint struct {
+	ID           int        `json:"id" yaml:"id"`
+	Name         string     `json:"name" yaml:"name"`
+	State        string     `json:"state" yaml:"state"`
+	Goal         string     `json:"goal,omitempty" yaml:"goal,omitempty"`
+	BoardID      int        `json:"boardId,omitempty" yaml:"boardId,omitempty"`
+	StartDate    *time.Time `json:"startDate,omitempty" yaml:"startDate,omitempty"`
+	EndDate      *time.Time `json:"endDate,omitempty" yaml:"endDate,omitempty"`
+	CompleteDate *time.Time `json:"completeDate,omitempty" yaml:"completeDate,omitempty"`
+}
+
+// agileSprint mirrors the sprint representation returned by the Jira Agile API
+type agileSprint struct {
+	ID            int    `json:"id"`
+	Name          string `json:"name"`
+	State         string `json:"state"`
+	Goal          string `json:"goal"`
+	OriginBoardID int    `json:"originBoardId"`
+	StartDate     string `json:"startDate"`
+	EndDate       string `json:"endDate"`
+	CompleteDate  string `json:"completeDate"`
+}
+
+// convertAgileSprint converts an Agile API sprint to our Sprint struct
+func convertAgileSprint(s agileSprint) Sprint {
+	sprint := Sprint{
+		ID:      s.ID,
+		Name:    s.Name,
+		State:   s.State,
+		Goal:    s.Goal,
+		BoardID: s.OriginBoardID,
+	}
+	if t, err := parseJiraTime(s.StartDate); err == nil {
+		sprint.StartDate = &t
+	}
+	if t, err := parseJiraTime(s.EndDate); err == nil {
+		sprint.EndDate = &t
+	}
+	if t, err := parseJiraTime(s.CompleteDate); err == nil {
+		sprint.CompleteDate = &t
+	}
+	return sprint
+}
+
+// FetchSprint retrieves a single sprint from the Jira Agile API
+func FetchSprint(baseURL, apikey string, sprintID int) (*Sprint, error) {
+	url := fmt.Sprintf("%s/rest/agile/1.0/sprint/%d", baseURL, sprintID)
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := getHTTPClient(apikey)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch sprint: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("jira API returned status %d: %s",
+			resp.StatusCode, string(bodyBytes))
+	}
+
+	var response agileSprint
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode sprint response: %w", err)
+	}
+
+	sprint := convertAgileSprint(response)
+	return &sprint, nil
+}
+
+// FetchBoardSprints retrieves the sprints of an agile board, following pagination.
+// state optionally filters by sprint state (future, active or closed).
+func FetchBoardSprints(baseURL, apikey string, boardID int, state string) ([]Sprint, error) {
+	httpClient := getHTTPClient(apikey)
+
+	var sprints []Sprint
+	startAt := 0
+	for {
+		url := fmt.Sprintf("%s/rest/agile/1.0/board/%d/sprint?startAt=%d", baseURL, boardID, startAt)
+		if state != "" {
+			url += "&state=" + state
+		}
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch board sprints: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("jira API returned status %d: %s",
+				resp.StatusCode, string(bodyBytes))
+		}
+
+		var response struct {
+			IsLast bool          `json:"isLast"`
+			Values []agileSprint `json:"values"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode board sprints response: %w", err)
+		}
+
+		for _, s := range response.Values {
+			sprints = append(sprints, convertAgileSprint(s))
+		}
+
+		if response.IsLast || len(response.Values) == 0 {
+			return sprints, nil
+		}
+		startAt += len(response.Values)
+	}
+}
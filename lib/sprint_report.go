@@ -0,0 +1,254 @@
+package lib
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Sprint scope and outcome classifications used in sprint reports
+const (
+	SprintScopeCommitted = "committed"
+	SprintScopeAdded     = "added"
+
+	SprintOutcomeCompleted   = "completed"
+	SprintOutcomeCarriedOver = "carried-over"
+	SprintOutcomeRemoved     = "removed"
+)
+
+// SprintReportIssue is a single issue classified against a sprint
+type SprintReportIssue struct {
+	Key      string  `json:"key" yaml:"key"`
+	Summary  string  `json:"summary" yaml:"summary"`
+	Status   string  `json:"status" yaml:"status"`
+	Assignee string  `json:"assignee,omitempty" yaml:"assignee,omitempty"`
+	Points   float64 `json:"points" yaml:"points"`
+	Scope    string  `json:"scope" yaml:"scope"`
+	Outcome  string  `json:"outcome" yaml:"outcome"`
+}
+
+// SprintReportCount totals a classification in issues and story points
+type SprintReportCount struct {
+	Issues int     `json:"issues" yaml:"issues"`
+	Points float64 `json:"points" yaml:"points"`
+}
+
+// SprintReportTotals holds the totals for each sprint classification
+type SprintReportTotals struct {
+	Committed   SprintReportCount `json:"committed" yaml:"committed"`
+	Added       SprintReportCount `json:"added" yaml:"added"`
+	Removed     SprintReportCount `json:"removed" yaml:"removed"`
+	Completed   SprintReportCount `json:"completed" yaml:"completed"`
+	CarriedOver SprintReportCount `json:"carriedOver" yaml:"carriedOver"`
+}
+
+// SprintReport compares what was committed to a sprint with what was completed
+type SprintReport struct {
+	Sprint Sprint              `json:"sprint" yaml:"sprint"`
+	Totals SprintReportTotals  `json:"totals" yaml:"totals"`
+	Issues []SprintReportIssue `json:"issues" yaml:"issues"`
+}
+
+// sprintReportEnd returns the point in time the sprint outcome is evaluated at
+func sprintReportEnd(sprint Sprint, now time.Time) time.Time {
+	end := now
+	if sprint.CompleteDate != nil {
+		end = *sprint.CompleteDate
+	} else if sprint.EndDate != nil && sprint.EndDate.Before(now) {
+		end = *sprint.EndDate
+	}
+	return end
+}
+
+// sprintNamesContain reports whether a Sprint field value includes the named sprint.
+// Jira stores the field history as a comma-separated list of sprint names.
+func sprintNamesContain(value, name string) bool {
+	for _, s := range strings.Split(value, ",") {
+		if strings.TrimSpace(s) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// pointsAt reconstructs the story points an issue carried at the given time
+func pointsAt(issue Issue, at time.Time) float64 {
+	current := strconv.FormatFloat(issue.StoryPoints, 'f', -1, 64)
+	value := fieldValueAt(fieldChanges(issue, "Story Points"), at, current)
+	points, err := strconv.ParseFloat(value, 64)
+	if err != nil {
+		return 0
+	}
+	return points
+}
+
+// BuildSprintReport classifies issues against a sprint by replaying their Sprint,
+// status and story point changes against the sprint start and end dates.
+// inSprint holds the keys of issues currently assigned to the sprint; it is only
+// consulted for issues whose Sprint field never changed. Jira records no Sprint change
+// when the sprint is set at creation, so an issue is never a member before it was created.
+func BuildSprintReport(sprint Sprint, issues []Issue, inSprint map[string]bool) (*SprintReport, error) {
+	if sprint.StartDate == nil {
+		return nil, fmt.Errorf("sprint %d has not started", sprint.ID)
+	}
+	start := *sprint.StartDate
+	end := sprintReportEnd(sprint, time.Now())
+
+	report := &SprintReport{Sprint: sprint}
+	for _, issue := range issues {
+		current := ""
+		if inSprint[issue.Key] {
+			current = sprint.Name
+		}
+		changes := fieldChanges(issue, "Sprint")
+		created, createdErr := parseJiraTime(issue.Created)
+		memberAt := func(t time.Time) bool {
+			if createdErr == nil && t.Before(created) {
+				return false
+			}
+			return sprintNamesContain(fieldValueAt(changes, t, current), sprint.Name)
+		}
+
+		atStart := memberAt(start)
+		enteredDuring := createdErr == nil && created.After(start) && !created.After(end) && memberAt(created)
+		for _, c := range changes {
+			if c.At.After(start) && !c.At.After(end) &&
+				sprintNamesContain(c.To, sprint.Name) && !sprintNamesContain(c.From, sprint.Name) {
+				enteredDuring = true
+				break
+			}
+		}
+		if !atStart && !enteredDuring {
+			continue
+		}
+
+		entry := SprintReportIssue{
+			Key:     issue.Key,
+			Summary: issue.Summary,
+			Status:  fieldValueAt(fieldChanges(issue, "status"), end, issue.Status.Name),
+			Points:  pointsAt(issue, end),
+		}
+		if issue.Assignee != nil {
+			entry.Assignee = issue.Assignee.DisplayName
+		}
+
+		if atStart {
+			entry.Scope = SprintScopeCommitted
+			report.Totals.Committed.Issues++
+			report.Totals.Committed.Points += pointsAt(issue, start)
+		} else {
+			entry.Scope = SprintScopeAdded
+			report.Totals.Added.Issues++
+			report.Totals.Added.Points += entry.Points
+		}
+
+		switch {
+		case !memberAt(end):
+			entry.Outcome = SprintOutcomeRemoved
+			report.Totals.Removed.Issues++
+			report.Totals.Removed.Points += entry.Points
+		case isDoneStatus(entry.Status):
+			entry.Outcome = SprintOutcomeCompleted
+			report.Totals.Completed.Issues++
+			report.Totals.Completed.Points += entry.Points
+		default:
+			entry.Outcome = SprintOutcomeCarriedOver
+			report.Totals.CarriedOver.Issues++
+			report.Totals.CarriedOver.Points += entry.Points
+		}
+
+		report.Issues = append(report.Issues, entry)
+	}
+
+	return report, nil
+}
+
+// FetchSprintReport fetches a sprint and its issues and builds a SprintReport.
+// scopeJQL optionally widens the candidate issues so that issues removed from the
+// sprint, which no longer match "sprint = <id>", can be detected.
+func FetchSprintReport(jiraURL, apikey string, sprintID int, scopeJQL string, verbose bool) (*SprintReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+
+	sprint, err := FetchSprint(jiraURL, apikey, sprintID)
+	if err != nil {
+		return nil, fmt.Errorf("fetching sprint %d: %w", sprintID, err)
+	}
+
+	return fetchSprintReportFor(jiraURL, apikey, *sprint, scopeJQL, verbose)
+}
+
+// fetchSprintReportFor fetches the issues of an already-fetched sprint and builds its report
+func fetchSprintReportFor(jiraURL, apikey string, sprint Sprint, scopeJQL string, verbose bool) (*SprintReport, error) {
+	issues, inSprint, err := fetchScopedIssues(jiraURL, apikey, fmt.Sprintf("sprint = %d", sprint.ID), scopeJQL, verbose)
+	if err != nil {
+		return nil, err
+	}
+	return BuildSprintReport(sprint, issues, inSprint)
+}
+
+// fetchScopedIssues fetches the issues matching jql with their history, followed by the
+// other issues matching the optional scopeJQL, which may include issues that left the scope.
+// matched holds the keys of the issues matching jql.
+func fetchScopedIssues(jiraURL, apikey, jql, scopeJQL string, verbose bool) (issues []Issue, matched map[string]bool, err error) {
+	issues, err = FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	matched = make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		matched[issue.Key] = true
+	}
+
+	if scopeJQL != "" {
+		extra, err := FetchIssuesWithHistory(jiraURL, apikey, scopeJQL, verbose)
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, issue := range extra {
+			if !matched[issue.Key] {
+				issues = append(issues, issue)
+			}
+		}
+	}
+	return issues, matched, nil
+}
+
+func printSprintReportTable(w *tabwriter.Writer, report *SprintReport) {
+	fmt.Fprintln(w, "KEY\tSCOPE\tOUTCOME\tPOINTS\tSTATUS\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%g\t%s\t%s\n",
+			issue.Key,
+			issue.Scope,
+			issue.Outcome,
+			issue.Points,
+			issue.Status,
+			truncateSummary(issue.Summary),
+		)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "TOTAL\tISSUES\tPOINTS")
+	for _, row := range sprintReportTotalRows(report.Totals) {
+		fmt.Fprintf(w, "%s\t%d\t%g\n", row.label, row.count.Issues, row.count.Points)
+	}
+}
+
+type sprintTotalRow struct {
+	label string
+	count SprintReportCount
+}
+
+// sprintReportTotalRows returns the totals in display order for the table and markdown renderers
+func sprintReportTotalRows(t SprintReportTotals) []sprintTotalRow {
+	return []sprintTotalRow{
+		{"Committed", t.Committed},
+		{"Added", t.Added},
+		{"Removed", t.Removed},
+		{"Completed", t.Completed},
+		{"Carried over", t.CarriedOver},
+	}
+}
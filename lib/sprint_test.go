@@ -0,0 +1,184 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFetchSprint tests fetching a sprint from the Agile API
+func TestFetchSprint(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/agile/1.0/sprint/42", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":            42,
+			"name":          "Sprint 42",
+			"state":         "closed",
+			"originBoardId": 7,
+			"startDate":     "2025-01-06T09:00:00.000Z",
+			"endDate":       "2025-01-20T09:00:00.000Z",
+			"completeDate":  "2025-01-20T10:00:00.000Z",
+		})
+	}))
+	defer server.Close()
+
+	sprint, err := FetchSprint(server.URL, "test-token", 42)
+	assert.NoError(t, err)
+	assert.Equal(t, "Sprint 42", sprint.Name)
+	assert.Equal(t, 7, sprint.BoardID)
+	assert.NotNil(t, sprint.StartDate)
+	assert.NotNil(t, sprint.CompleteDate)
+	assert.Equal(t, 20, sprint.CompleteDate.Day())
+}
+
+// TestFetchSprintError tests error handling for sprints
+func TestFetchSprintError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	sprint, err := FetchSprint(server.URL, "test-token", 1)
+	assert.Error(t, err)
+	assert.Nil(t, sprint)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func testSprint() Sprint {
+	start := time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+	end := time.Date(2025, 1, 20, 9, 0, 0, 0, time.UTC)
+	return Sprint{ID: 42, Name: "Sprint 42", State: "closed", StartDate: &start, EndDate: &end, CompleteDate: &end}
+}
+
+func sprintChange(at time.Time, from, to string) HistoryItem {
+	return HistoryItem{Created: at, Items: []HistoryChange{{Field: "Sprint", FromString: from, ToString: to}}}
+}
+
+func statusChange(at time.Time, from, to string) HistoryItem {
+	return HistoryItem{Created: at, Items: []HistoryChange{{Field: "status", FromString: from, ToString: to}}}
+}
+
+func TestBuildSprintReport(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2025, 1, d, 12, 0, 0, 0, time.UTC) }
+	issues := []Issue{
+		{
+			// Committed and completed, with points re-estimated mid sprint
+			Key: "CNF-1", Status: Status{Name: "Done"}, StoryPoints: 5,
+			History: []HistoryItem{
+				sprintChange(day(3), "", "Sprint 42"),
+				{Created: day(8), Items: []HistoryChange{{Field: "Story Points", FromString: "3", ToString: "5"}}},
+				statusChange(day(15), "In Progress", "Done"),
+			},
+		},
+		{
+			// Committed with no sprint history and carried over
+			Key: "CNF-2", Status: Status{Name: "In Progress"}, StoryPoints: 2,
+		},
+		{
+			// Added mid sprint and completed
+			Key: "CNF-3", Status: Status{Name: "Closed"}, StoryPoints: 1,
+			History: []HistoryItem{
+				sprintChange(day(10), "Sprint 41", "Sprint 41, Sprint 42"),
+				statusChange(day(12), "Open", "Closed"),
+			},
+		},
+		{
+			// Committed then removed
+			Key: "CNF-4", Status: Status{Name: "Open"}, StoryPoints: 8,
+			History: []HistoryItem{
+				sprintChange(day(2), "", "Sprint 42"),
+				sprintChange(day(9), "Sprint 42", ""),
+			},
+		},
+		{
+			// Never part of the sprint
+			Key: "CNF-5", Status: Status{Name: "Open"}, StoryPoints: 3,
+		},
+		{
+			// Added after the sprint completed
+			Key: "CNF-6", Status: Status{Name: "Open"},
+			History: []HistoryItem{sprintChange(day(25), "", "Sprint 42")},
+		},
+		{
+			// Created straight into the running sprint, which records no Sprint change
+			Key: "CNF-7", Status: Status{Name: "Open"}, StoryPoints: 3, Created: "2025-01-10T12:00:00.000+0000",
+		},
+	}
+	inSprint := map[string]bool{"CNF-1": true, "CNF-2": true, "CNF-3": true, "CNF-6": true, "CNF-7": true}
+
+	report, err := BuildSprintReport(testSprint(), issues, inSprint)
+	assert.NoError(t, err)
+	assert.Len(t, report.Issues, 5)
+
+	byKey := map[string]SprintReportIssue{}
+	for _, issue := range report.Issues {
+		byKey[issue.Key] = issue
+	}
+	assert.Equal(t, SprintScopeCommitted, byKey["CNF-1"].Scope)
+	assert.Equal(t, SprintOutcomeCompleted, byKey["CNF-1"].Outcome)
+	assert.Equal(t, SprintScopeCommitted, byKey["CNF-2"].Scope)
+	assert.Equal(t, SprintOutcomeCarriedOver, byKey["CNF-2"].Outcome)
+	assert.Equal(t, SprintScopeAdded, byKey["CNF-3"].Scope)
+	assert.Equal(t, SprintOutcomeCompleted, byKey["CNF-3"].Outcome)
+	assert.Equal(t, SprintScopeCommitted, byKey["CNF-4"].Scope)
+	assert.Equal(t, SprintOutcomeRemoved, byKey["CNF-4"].Outcome)
+	assert.Equal(t, SprintScopeAdded, byKey["CNF-7"].Scope)
+	assert.Equal(t, SprintOutcomeCarriedOver, byKey["CNF-7"].Outcome)
+
+	assert.Equal(t, SprintReportCount{Issues: 3, Points: 13}, report.Totals.Committed)
+	assert.Equal(t, SprintReportCount{Issues: 2, Points: 4}, report.Totals.Added)
+	assert.Equal(t, SprintReportCount{Issues: 1, Points: 8}, report.Totals.Removed)
+	assert.Equal(t, SprintReportCount{Issues: 2, Points: 6}, report.Totals.Completed)
+	assert.Equal(t, SprintReportCount{Issues: 2, Points: 5}, report.Totals.CarriedOver)
+}
+
+func TestBuildSprintReport_NotStarted(t *testing.T) {
+	report, err := BuildSprintReport(Sprint{ID: 1, Name: "Future"}, nil, nil)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestFetchSprintReport_EmptyConfig(t *testing.T) {
+	report, err := FetchSprintReport("", "", 1, "", false)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestPrintTable_SprintReport(t *testing.T) {
+	report, err := BuildSprintReport(testSprint(), []Issue{{Key: "CNF-1", Status: Status{Name: "Done"}, StoryPoints: 3}}, map[string]bool{"CNF-1": true})
+	assert.NoError(t, err)
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+}
+
+// TestFetchBoardSprints tests paginating through board sprints
+func TestFetchBoardSprints(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/agile/1.0/board/7/sprint", r.URL.Path)
+		assert.Equal(t, "closed", r.URL.Query().Get("state"))
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Query().Get("startAt") == "0" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"isLast": false,
+				"values": []map[string]interface{}{{"id": 1, "name": "Sprint 1", "state": "closed"}},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"isLast": true,
+			"values": []map[string]interface{}{{"id": 2, "name": "Sprint 2", "state": "closed"}},
+		})
+	}))
+	defer server.Close()
+
+	sprints, err := FetchBoardSprints(server.URL, "test-token", 7, "closed")
+	assert.NoError(t, err)
+	assert.Len(t, sprints, 2)
+	assert.Equal(t, "Sprint 2", sprints[1].Name)
+}
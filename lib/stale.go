@@ -0,0 +1,301 @@
+package lib
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Kinds of activity that keep an issue fresh
+const (
+	StaleActivityCreated = "created"
+	StaleActivityComment = "comment"
+	StaleActivityChange  = "change"
+)
+
+// DefaultStaleDays is the number of days without human activity after which an issue is stale
+const DefaultStaleDays = 30
+
+// StaleIssue is an open issue without human activity in the window. LastActivity is empty
+// when the issue never had any.
+type StaleIssue struct {
+	Key            string   `json:"key" yaml:"key"`
+	Summary        string   `json:"summary" yaml:"summary"`
+	Status         string   `json:"status" yaml:"status"`
+	Assignee       string   `json:"assignee" yaml:"assignee"`
+	Components     []string `json:"components,omitempty" yaml:"components,omitempty"`
+	LastActivity   string   `json:"lastActivity,omitempty" yaml:"lastActivity,omitempty"`
+	LastActivityBy string   `json:"lastActivityBy,omitempty" yaml:"lastActivityBy,omitempty"`
+	LastAction     string   `json:"lastAction,omitempty" yaml:"lastAction,omitempty"`
+	IdleDays       float64  `json:"idleDays" yaml:"idleDays"`
+}
+
+// StaleGroup counts the stale issues of one assignee or component
+type StaleGroup struct {
+	Name   string   `json:"name" yaml:"name"`
+	Count  int      `json:"count" yaml:"count"`
+	Issues []string `json:"issues" yaml:"issues"`
+}
+
+// StaleReport lists the open issues without human activity for Days days, longest idle
+// first, grouped by assignee and by component
+type StaleReport struct {
+	JQL         string       `json:"jql" yaml:"jql"`
+	Days        int          `json:"days" yaml:"days"`
+	Bots        []string     `json:"bots,omitempty" yaml:"bots,omitempty"`
+	Checked     int          `json:"checked" yaml:"checked"`
+	ByAssignee  []StaleGroup `json:"byAssignee" yaml:"byAssignee"`
+	ByComponent []StaleGroup `json:"byComponent" yaml:"byComponent"`
+	Issues      []StaleIssue `json:"issues" yaml:"issues"`
+}
+
+// isBot reports whether an author, given by display name and user name, is in the bot list
+func isBot(bots []string, displayName, name string) bool {
+	for _, bot := range bots {
+		if (displayName != "" && strings.EqualFold(bot, displayName)) || (name != "" && strings.EqualFold(bot, name)) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastHumanActivity returns the latest creation, comment or change of the issue by an author
+// who is not a bot. Updated is not used since automation bumps it.
+func lastHumanActivity(issue Issue, bots []string) (at time.Time, by, action string) {
+	record := func(t time.Time, author, kind string) {
+		if t.After(at) {
+			at, by, action = t, author, kind
+		}
+	}
+
+	if created, err := parseJiraTime(issue.Created); err == nil {
+		creator := issue.Creator
+		if creator == nil {
+			creator = issue.Reporter
+		}
+		switch {
+		case creator == nil:
+			record(created, "", StaleActivityCreated)
+		case !isBot(bots, creator.DisplayName, creator.Name) && !isBot(bots, "", creator.AccountID):
+			record(created, creator.DisplayName, StaleActivityCreated)
+		}
+	}
+	for _, c := range issue.Comments {
+		if !isBot(bots, c.Author, c.AuthorName) {
+			record(c.Created, c.Author, StaleActivityComment)
+		}
+	}
+	for _, h := range issue.History {
+		if !isBot(bots, h.Author, h.AuthorName) {
+			record(h.Created, h.Author, StaleActivityChange)
+		}
+	}
+	return at, by, action
+}
+
+// staleGroups sorts the groups by count, highest first, and then by name
+func staleGroups(groups map[string]*StaleGroup) []StaleGroup {
+	sorted := make([]StaleGroup, 0, len(groups))
+	for _, g := range groups {
+		sorted = append(sorted, *g)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Count != sorted[j].Count {
+			return sorted[i].Count > sorted[j].Count
+		}
+		return sorted[i].Name < sorted[j].Name
+	})
+	return sorted
+}
+
+// BuildStaleReport finds the open issues whose last human activity, the latest of their
+// creation, comments and changes by authors not in bots, is more than days before now.
+// Issues that are resolved or in a status of the done category are not open. The issues
+// need their comments and history. An issue with several components counts in each.
+func BuildStaleReport(issues []Issue, categories StatusCategories, days int, bots []string, now time.Time) *StaleReport {
+	report := &StaleReport{Days: days, Bots: bots, ByAssignee: []StaleGroup{}, ByComponent: []StaleGroup{}, Issues: []StaleIssue{}}
+	cutoff := now.AddDate(0, 0, -days)
+	byAssignee, byComponent := map[string]*StaleGroup{}, map[string]*StaleGroup{}
+	add := func(groups map[string]*StaleGroup, name, key string) {
+		if groups[name] == nil {
+			groups[name] = &StaleGroup{Name: name}
+		}
+		groups[name].Count++
+		groups[name].Issues = append(groups[name].Issues, key)
+	}
+
+	for _, issue := range issues {
+		if !isOpenIssue(issue, categories) {
+			continue
+		}
+		report.Checked++
+
+		at, by, action := lastHumanActivity(issue, bots)
+		if at.After(cutoff) {
+			continue
+		}
+		si := StaleIssue{
+			Key:        issue.Key,
+			Summary:    issue.Summary,
+			Status:     issue.Status.Name,
+			Assignee:   userDisplayName(issue.Assignee),
+			Components: issue.Components,
+		}
+		if !at.IsZero() {
+			si.LastActivity = at.Format(time.RFC3339)
+			si.LastActivityBy = by
+			si.LastAction = action
+			si.IdleDays = now.Sub(at).Hours() / 24
+		}
+		report.Issues = append(report.Issues, si)
+
+		add(byAssignee, si.Assignee, si.Key)
+		if len(si.Components) == 0 {
+			add(byComponent, "No component", si.Key)
+		}
+		for _, component := range si.Components {
+			add(byComponent, component, si.Key)
+		}
+	}
+
+	sort.SliceStable(report.Issues, func(i, j int) bool {
+		a, b := report.Issues[i], report.Issues[j]
+		if (a.LastActivity == "") != (b.LastActivity == "") {
+			return a.LastActivity == ""
+		}
+		return a.IdleDays > b.IdleDays
+	})
+	report.ByAssignee = staleGroups(byAssignee)
+	report.ByComponent = staleGroups(byComponent)
+	return report
+}
+
+// isOpenIssue reports whether an issue is neither resolved nor in a status of the done category
+func isOpenIssue(issue Issue, categories StatusCategories) bool {
+	return issue.Resolved == "" && categories.Category(issue.Status.Name) != CategoryDone
+}
+
+// FetchStaleReport fetches the issues matching the JQL query and finds the stale ones. The
+// history and comments are only fetched for the open issues. See BuildStaleReport.
+func FetchStaleReport(jiraURL, apikey, jql string, categories StatusCategories, days int, bots []string, verbose bool) (*StaleReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if jql == "" {
+		return nil, fmt.Errorf("JQL query must not be empty")
+	}
+	if days <= 0 {
+		return nil, fmt.Errorf("days must be positive")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+	found, err := searchAllIssues(client, jql)
+	if err != nil {
+		return nil, err
+	}
+	var issues []Issue
+	for _, issue := range found {
+		if isOpenIssue(issue, categories) {
+			issues = append(issues, issue)
+		}
+	}
+
+	rl := GetGlobalRateLimiter()
+	for i := range issues {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching history and comments for %s (%d/%d)...\n", issues[i].Key, i+1, len(issues))
+		}
+		rl.Wait()
+		history, err := FetchIssueHistory(client, jiraURL, issues[i].Key, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching history for %s: %w", issues[i].Key, err)
+		}
+		issues[i].History = history
+
+		rl.Wait()
+		comments, err := FetchIssueComments(client, jiraURL, issues[i].Key, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching comments for %s: %w", issues[i].Key, err)
+		}
+		issues[i].Comments = comments
+	}
+
+	report := BuildStaleReport(issues, categories, days, bots, time.Now())
+	report.JQL = jql
+	return report, nil
+}
+
+// formatStaleIdle formats the idle days of an issue, or "-" when it never had activity
+func formatStaleIdle(issue StaleIssue) string {
+	if issue.LastActivity == "" {
+		return "-"
+	}
+	return fmt.Sprintf("%.1f", issue.IdleDays)
+}
+
+// formatStaleActivity describes the last human activity of an issue
+func formatStaleActivity(issue StaleIssue) string {
+	if issue.LastActivity == "" {
+		return "never"
+	}
+	activity := fmt.Sprintf("%s %s", issue.LastAction, issue.LastActivity[:10])
+	if issue.LastActivityBy != "" {
+		activity += " by " + issue.LastActivityBy
+	}
+	return activity
+}
+
+func printStaleTable(w *tabwriter.Writer, report *StaleReport) {
+	fmt.Fprintln(w, "KEY\tIDLE DAYS\tLAST ACTIVITY\tSTATUS\tASSIGNEE\tCOMPONENTS\tSUMMARY")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", issue.Key, formatStaleIdle(issue), formatStaleActivity(issue), issue.Status,
+			issue.Assignee, strings.Join(issue.Components, ", "), truncateSummary(issue.Summary))
+	}
+	for _, section := range []struct {
+		title  string
+		groups []StaleGroup
+	}{{"ASSIGNEE", report.ByAssignee}, {"COMPONENT", report.ByComponent}} {
+		if len(section.groups) == 0 {
+			continue
+		}
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "%s\tSTALE\tISSUES\n", section.title)
+		for _, g := range section.groups {
+			fmt.Fprintf(w, "%s\t%d\t%s\n", g.Name, g.Count, strings.Join(g.Issues, ", "))
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Stale:\t%d of %d open issues without activity for %d days\n", len(report.Issues), report.Checked, report.Days)
+}
+
+func writeStaleMarkdown(b *strings.Builder, report *StaleReport) {
+	b.WriteString("# Stale issues\n\n")
+	fmt.Fprintf(b, "%d of %d open issues without human activity for %d days.\n\n", len(report.Issues), report.Checked, report.Days)
+	if len(report.Issues) == 0 {
+		return
+	}
+	b.WriteString("| Key | Idle days | Last activity | Status | Assignee | Components | Summary |\n")
+	b.WriteString("|-----|-----------|---------------|--------|----------|------------|---------|\n")
+	for _, issue := range report.Issues {
+		fmt.Fprintf(b, "| %s | %s | %s | %s | %s | %s | %s |\n", issue.Key, formatStaleIdle(issue),
+			escapeMarkdown(formatStaleActivity(issue)), escapeMarkdown(issue.Status), escapeMarkdown(issue.Assignee),
+			escapeMarkdown(strings.Join(issue.Components, ", ")), escapeMarkdown(issue.Summary))
+	}
+	for _, section := range []struct {
+		title  string
+		groups []StaleGroup
+	}{{"Assignee", report.ByAssignee}, {"Component", report.ByComponent}} {
+		fmt.Fprintf(b, "\n## By %s\n\n", strings.ToLower(section.title))
+		fmt.Fprintf(b, "| %s | Stale | Issues |\n", section.title)
+		b.WriteString("|------|-------|--------|\n")
+		for _, g := range section.groups {
+			fmt.Fprintf(b, "| %s | %d | %s |\n", escapeMarkdown(g.Name), g.Count, strings.Join(g.Issues, ", "))
+		}
+	}
+}
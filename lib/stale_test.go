@@ -0,0 +1,121 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStaleReport(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	day := func(month time.Month, d int) time.Time { return time.Date(2024, month, d, 0, 0, 0, 0, time.UTC) }
+	alice := &User{Name: "alice", DisplayName: "Alice"}
+	bot := &User{Name: "jirabot", DisplayName: "Jira Bot"}
+	issues := []Issue{
+		{
+			// Only bots touched it after it was created
+			Key: "S-1", Created: "2024-01-01T00:00:00Z", Creator: alice, Status: Status{Name: "New"},
+			Assignee: &User{DisplayName: "Bob"}, Components: []string{"API"},
+			Comments: []Comment{{Author: "Jira Bot", AuthorName: "jirabot", Created: day(2, 20)}},
+			History:  []HistoryItem{{Author: "Automation", Created: day(2, 25)}},
+		},
+		{
+			Key: "S-2", Created: "2024-01-01T00:00:00Z", Creator: alice, Status: Status{Name: "New"},
+			Comments: []Comment{{Author: "Alice", AuthorName: "alice", Created: day(2, 20)}},
+		},
+		{
+			// Created by a bot and last moved by a human
+			Key: "S-3", Created: "2024-01-05T00:00:00Z", Creator: bot, Status: Status{Name: "Coding"},
+			Components: []string{"API", "UI"},
+			History:    []HistoryItem{{Author: "Carol", AuthorName: "carol", Created: day(1, 10)}},
+		},
+		{Key: "S-4", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "New"}, Resolved: "2024-01-02T00:00:00Z"},
+		{Key: "S-5", Created: "2024-01-01T00:00:00Z", Status: Status{Name: "Done"}},
+		{Key: "S-6", Status: Status{Name: "New"}},
+	}
+	bots := []string{"JIRABOT", "Automation"}
+
+	report := BuildStaleReport(issues, nil, DefaultStaleDays, bots, day(3, 1))
+	assert.Equal(t, 4, report.Checked)
+	assert.Len(t, report.Issues, 3)
+
+	assert.Equal(t, "S-6", report.Issues[0].Key)
+	assert.Empty(t, report.Issues[0].LastActivity)
+
+	assert.Equal(t, "S-1", report.Issues[1].Key)
+	assert.Equal(t, "2024-01-01T00:00:00Z", report.Issues[1].LastActivity)
+	assert.Equal(t, "Alice", report.Issues[1].LastActivityBy)
+	assert.Equal(t, StaleActivityCreated, report.Issues[1].LastAction)
+	assert.Equal(t, 60.0, report.Issues[1].IdleDays)
+
+	assert.Equal(t, "S-3", report.Issues[2].Key)
+	assert.Equal(t, "Carol", report.Issues[2].LastActivityBy)
+	assert.Equal(t, StaleActivityChange, report.Issues[2].LastAction)
+
+	assert.Equal(t, []StaleGroup{
+		{Name: "Unassigned", Count: 2, Issues: []string{"S-3", "S-6"}},
+		{Name: "Bob", Count: 1, Issues: []string{"S-1"}},
+	}, report.ByAssignee)
+	assert.Equal(t, []StaleGroup{
+		{Name: "API", Count: 2, Issues: []string{"S-1", "S-3"}},
+		{Name: "No component", Count: 1, Issues: []string{"S-6"}},
+		{Name: "UI", Count: 1, Issues: []string{"S-3"}},
+	}, report.ByComponent)
+
+	// Without the bot list the automation keeps S-1 fresh
+	assert.Len(t, BuildStaleReport(issues, nil, DefaultStaleDays, nil, day(3, 1)).Issues, 2)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
+
+func TestFetchStaleReport_Validation(t *testing.T) {
+	_, err := FetchStaleReport("", "", "project = TEST", nil, DefaultStaleDays, nil, false)
+	assert.Error(t, err)
+	_, err = FetchStaleReport("https://jira.example.com", "token", "", nil, DefaultStaleDays, nil, false)
+	assert.Error(t, err)
+	_, err = FetchStaleReport("https://jira.example.com", "token", "project = TEST", nil, 0, nil, false)
+	assert.Error(t, err)
+}
+
+func TestFetchStaleReport_OnlyOpenIssues(t *testing.T) {
+	var fetched []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"startAt": 0, "maxResults": 100, "total": 3,
+				"issues": []map[string]interface{}{
+					{"key": "TEST-1", "fields": map[string]interface{}{"status": map[string]string{"name": "In Progress"}, "created": "2020-01-01T08:00:00.000+0000"}},
+					{"key": "TEST-2", "fields": map[string]interface{}{"status": map[string]string{"name": "Done"}, "created": "2020-01-01T08:00:00.000+0000"}},
+					{"key": "TEST-3", "fields": map[string]interface{}{"status": map[string]string{"name": "New"}, "resolutiondate": "2020-02-01T08:00:00.000+0000"}},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			fetched = append(fetched, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"comments": []interface{}{}})
+		case strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/"):
+			fetched = append(fetched, r.URL.Path)
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"changelog": map[string]interface{}{"histories": []interface{}{}}})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	report, err := FetchStaleReport(server.URL, "token", "project = TEST", nil, DefaultStaleDays, nil, false)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, report.Checked)
+	assert.Len(t, report.Issues, 1)
+	assert.Equal(t, "TEST-1", report.Issues[0].Key)
+	assert.Equal(t, []string{"/rest/api/2/issue/TEST-1", "/rest/api/2/issue/TEST-1/comment"}, fetched)
+}
@@ -0,0 +1,229 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+)
+
+// DefaultBlockedStatuses lists the status names treated as blocked work. It is empty since
+// Jira rejects JQL naming a status the instance does not have, such as Blocked.
+var DefaultBlockedStatuses = []string{}
+
+// DefaultFlaggedField is the JQL name of the Jira Software field used to flag impediments
+const DefaultFlaggedField = "Flagged"
+
+// StandupItem is one issue listed in a standup section
+type StandupItem struct {
+	Key     string `json:"key" yaml:"key"`
+	Summary string `json:"summary" yaml:"summary"`
+	Status  string `json:"status,omitempty" yaml:"status,omitempty"`
+	Detail  string `json:"detail,omitempty" yaml:"detail,omitempty"`
+}
+
+// StandupEntry is what one person did and what blocks them
+type StandupEntry struct {
+	User         string        `json:"user" yaml:"user"`
+	Done         []StandupItem `json:"done" yaml:"done"`
+	Transitioned []StandupItem `json:"transitioned" yaml:"transitioned"`
+	Commented    []StandupItem `json:"commented" yaml:"commented"`
+	Blocked      []StandupItem `json:"blocked" yaml:"blocked"`
+}
+
+// StandupReport is the standup digest of several people
+type StandupReport struct {
+	Team      string         `json:"team,omitempty" yaml:"team,omitempty"`
+	DateRange string         `json:"dateRange" yaml:"dateRange"`
+	Users     []StandupEntry `json:"users" yaml:"users"`
+}
+
+// isEmpty reports whether the entry has nothing to report
+func (e StandupEntry) isEmpty() bool {
+	return len(e.Done) == 0 && len(e.Transitioned) == 0 && len(e.Commented) == 0 && len(e.Blocked) == 0
+}
+
+// BuildStandupEntry groups a user's activity into issues moved to Done, other transitions and
+// comments, one item per issue, and lists the blocked issues. Transitions on one issue are
+// collapsed into the first and last status; an issue that ended in a done status is only
+// listed as done.
+func BuildStandupEntry(activity UserActivity, blocked []Issue, blockedStatuses []string) StandupEntry {
+	entry := StandupEntry{User: activity.User}
+
+	type transition struct {
+		item StandupItem
+		from string
+		to   string
+	}
+	var order []string
+	transitions := make(map[string]*transition)
+	comments := make(map[string]int)
+	for _, e := range activity.Events {
+		switch e.Action {
+		case ActivityTransitioned:
+			t, ok := transitions[e.IssueKey]
+			if !ok {
+				t = &transition{item: StandupItem{Key: e.IssueKey, Summary: e.Summary}, from: e.From}
+				transitions[e.IssueKey] = t
+				order = append(order, e.IssueKey)
+			}
+			t.to = e.To
+		case ActivityCommented:
+			if i, ok := comments[e.IssueKey]; ok {
+				// Keep the latest comment
+				entry.Commented[i].Detail = e.Detail
+				continue
+			}
+			comments[e.IssueKey] = len(entry.Commented)
+			entry.Commented = append(entry.Commented, StandupItem{Key: e.IssueKey, Summary: e.Summary, Detail: e.Detail})
+		}
+	}
+
+	for _, key := range order {
+		t := transitions[key]
+		t.item.Status = t.to
+		if isDoneStatus(t.to) {
+			entry.Done = append(entry.Done, t.item)
+			continue
+		}
+		t.item.Detail = fmt.Sprintf("%s → %s", valueOrNone(t.from), valueOrNone(t.to))
+		entry.Transitioned = append(entry.Transitioned, t.item)
+	}
+
+	for _, issue := range blocked {
+		reason := "flagged"
+		if statusIn(issue.Status.Name, blockedStatuses) {
+			reason = issue.Status.Name
+		}
+		entry.Blocked = append(entry.Blocked, StandupItem{
+			Key:     issue.Key,
+			Summary: issue.Summary,
+			Status:  issue.Status.Name,
+			Detail:  reason,
+		})
+	}
+	return entry
+}
+
+// statusIn reports whether the status is one of the given status names
+func statusIn(status string, statuses []string) bool {
+	for _, s := range statuses {
+		if strings.EqualFold(s, status) {
+			return true
+		}
+	}
+	return false
+}
+
+// blockedClause returns the JQL condition matching issues in one of the blocked statuses or
+// with the flagged field set. An empty flaggedField only checks the status.
+func blockedClause(blockedStatuses []string, flaggedField string) string {
+	var clauses []string
+	if len(blockedStatuses) > 0 {
+		quoted := make([]string, len(blockedStatuses))
+		for i, s := range blockedStatuses {
+			quoted[i] = fmt.Sprintf("%q", s)
+		}
+		clauses = append(clauses, fmt.Sprintf("status in (%s)", strings.Join(quoted, ", ")))
+	}
+	if flaggedField != "" {
+		clauses = append(clauses, fmt.Sprintf(`"%s" is not EMPTY`, flaggedField))
+	}
+	return strings.Join(clauses, " OR ")
+}
+
+// blockedJQL selects the unresolved issues assigned to the user that are blocked
+func blockedJQL(user string, blockedStatuses []string, flaggedField string) string {
+	clause := blockedClause(blockedStatuses, flaggedField)
+	if clause == "" {
+		return ""
+	}
+	return fmt.Sprintf(`assignee = "%s" AND resolution = Unresolved AND (%s) ORDER BY updated DESC`, user, clause)
+}
+
+// FetchStandup builds a standup digest of what each user moved to Done, transitioned and
+// commented on since the given date, and of their blocked issues. since accepts any form
+// understood by ParseDateRange. Issues are blocked when their status is one of
+// blockedStatuses or the flaggedField (a JQL field name) is set.
+func FetchStandup(jiraURL, jiraUser, apikey string, users []string, since string, blockedStatuses []string, flaggedField string, verbose bool) (*StandupReport, error) {
+	activity, err := FetchUserActivity(jiraURL, jiraUser, apikey, users, since, "now", verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	rl := GetGlobalRateLimiter()
+	report := &StandupReport{DateRange: activity.DateRange}
+	for _, a := range activity.Users {
+		var blocked []Issue
+		if jql := blockedJQL(a.User, blockedStatuses, flaggedField); jql != "" {
+			rl.Wait()
+			blocked, err = searchAllIssues(client, jql)
+			if err != nil {
+				return nil, fmt.Errorf("searching blocked issues of %s: %w", a.User, err)
+			}
+		}
+		report.Users = append(report.Users, BuildStandupEntry(a, blocked, blockedStatuses))
+	}
+	return report, nil
+}
+
+// standupSection is a titled list of standup items
+type standupSection struct {
+	title string
+	items []StandupItem
+}
+
+// standupSections returns the sections of an entry in display order
+func standupSections(e StandupEntry) []standupSection {
+	return []standupSection{
+		{"Done", e.Done},
+		{"Transitioned", e.Transitioned},
+		{"Commented", e.Commented},
+		{"Blocked", e.Blocked},
+	}
+}
+
+func printStandupTable(w *tabwriter.Writer, report *StandupReport) {
+	fmt.Fprintln(w, "USER\tSECTION\tKEY\tDETAIL\tSUMMARY")
+	for _, u := range report.Users {
+		for _, section := range standupSections(u) {
+			for _, item := range section.items {
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", u.User, strings.ToLower(section.title), item.Key, item.Detail, truncateSummary(item.Summary))
+			}
+		}
+	}
+}
+
+// writeStandupMarkdown writes the digest as headings and bullet lists, which paste cleanly into chat
+func writeStandupMarkdown(b *strings.Builder, report *StandupReport) {
+	title := "Standup"
+	if report.Team != "" {
+		title += ": " + report.Team
+	}
+	fmt.Fprintf(b, "# %s (%s)\n\n", escapeMarkdown(title), report.DateRange)
+	for _, u := range report.Users {
+		fmt.Fprintf(b, "## %s\n\n", escapeMarkdown(u.User))
+		if u.isEmpty() {
+			b.WriteString("Nothing to report.\n\n")
+			continue
+		}
+		for _, section := range standupSections(u) {
+			if len(section.items) == 0 {
+				continue
+			}
+			fmt.Fprintf(b, "**%s**\n", section.title)
+			for _, item := range section.items {
+				fmt.Fprintf(b, "- %s %s", item.Key, escapeMarkdown(item.Summary))
+				if item.Detail != "" {
+					fmt.Fprintf(b, " (%s)", escapeMarkdown(item.Detail))
+				}
+				b.WriteString("\n")
+			}
+			b.WriteString("\n")
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildStandupEntry(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2024, 1, 15, hour, 0, 0, 0, time.UTC) }
+	activity := UserActivity{
+		User: "jdoe",
+		Events: []ActivityEvent{
+			{At: at(9), IssueKey: "TEST-1", Summary: "Fix login", Action: ActivityTransitioned, From: "To Do", To: "In Progress"},
+			{At: at(10), IssueKey: "TEST-1", Summary: "Fix login", Action: ActivityTransitioned, From: "In Progress", To: "Review"},
+			{At: at(11), IssueKey: "TEST-2", Summary: "Add logout", Action: ActivityTransitioned, From: "Review", To: "Done"},
+			{At: at(12), IssueKey: "TEST-3", Summary: "Docs", Action: ActivityCommented, Detail: "first"},
+			{At: at(13), IssueKey: "TEST-3", Summary: "Docs", Action: ActivityCommented, Detail: "second"},
+			{At: at(14), IssueKey: "TEST-1", Summary: "Fix login", Action: ActivityUpdated, Detail: "labels"},
+		},
+	}
+	blocked := []Issue{
+		{Key: "TEST-4", Summary: "Upgrade", Status: Status{Name: "Blocked"}},
+		{Key: "TEST-5", Summary: "Migrate", Status: Status{Name: "In Progress"}},
+	}
+
+	entry := BuildStandupEntry(activity, blocked, []string{"Blocked"})
+	assert.Equal(t, "jdoe", entry.User)
+
+	assert.Len(t, entry.Done, 1)
+	assert.Equal(t, "TEST-2", entry.Done[0].Key)
+
+	assert.Len(t, entry.Transitioned, 1)
+	assert.Equal(t, "To Do → Review", entry.Transitioned[0].Detail)
+	assert.Equal(t, "Review", entry.Transitioned[0].Status)
+
+	assert.Len(t, entry.Commented, 1)
+	assert.Equal(t, "second", entry.Commented[0].Detail)
+
+	assert.Len(t, entry.Blocked, 2)
+	assert.Equal(t, "Blocked", entry.Blocked[0].Detail)
+	assert.Equal(t, "flagged", entry.Blocked[1].Detail)
+
+	assert.True(t, BuildStandupEntry(UserActivity{User: "idle"}, nil, nil).isEmpty())
+}
+
+func TestBlockedJQL(t *testing.T) {
+	jql := blockedJQL("jdoe", []string{"Blocked", "On Hold"}, "Flagged")
+	assert.Equal(t, `assignee = "jdoe" AND resolution = Unresolved AND (status in ("Blocked", "On Hold") OR "Flagged" is not EMPTY) ORDER BY updated DESC`, jql)
+
+	assert.NotContains(t, blockedJQL("jdoe", []string{"Blocked"}, ""), "Flagged")
+	assert.Empty(t, blockedJQL("jdoe", nil, ""))
+
+	// The defaults name no status, which may not exist on the instance
+	assert.Equal(t, `assignee = "jdoe" AND resolution = Unresolved AND ("Flagged" is not EMPTY) ORDER BY updated DESC`,
+		blockedJQL("jdoe", DefaultBlockedStatuses, DefaultFlaggedField))
+}
+
+func TestFetchStandup(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			issue := map[string]interface{}{"key": "TEST-1", "fields": map[string]interface{}{"summary": "Fix login", "created": "2023-12-01T08:00:00.000+0000"}}
+			if strings.Contains(r.URL.Query().Get("jql"), "resolution = Unresolved") {
+				issue = map[string]interface{}{"key": "TEST-9", "fields": map[string]interface{}{"summary": "Upgrade", "status": map[string]string{"name": "Blocked"}}}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"startAt": 0, "maxResults": 100, "total": 1,
+				"issues": []map[string]interface{}{issue},
+			})
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"comments": []map[string]interface{}{}})
+		case r.URL.Path == "/rest/api/2/issue/TEST-1":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"changelog": map[string]interface{}{
+					"histories": []map[string]interface{}{
+						{"id": "1", "author": map[string]string{"name": "jdoe"}, "created": time.Now().Add(-time.Minute).UTC().Format("2006-01-02T15:04:05.000-0700"),
+							"items": []map[string]string{{"field": "status", "fromString": "In Progress", "toString": "Done"}}},
+					},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	report, err := FetchStandup(server.URL, "me", "token", []string{"jdoe"}, "-1d", DefaultBlockedStatuses, DefaultFlaggedField, false)
+	assert.NoError(t, err)
+	assert.Len(t, report.Users, 1)
+	assert.Len(t, report.Users[0].Done, 1)
+	assert.Equal(t, "TEST-1", report.Users[0].Done[0].Key)
+	assert.Len(t, report.Users[0].Blocked, 1)
+	assert.Equal(t, "TEST-9", report.Users[0].Blocked[0].Key)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+}
@@ -0,0 +1,27 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMean(t *testing.T) {
+	assert.Equal(t, 0.0, mean(nil))
+	assert.Equal(t, 2.5, mean([]float64{1, 2, 3, 4}))
+}
+
+func TestStdDev(t *testing.T) {
+	assert.Equal(t, 0.0, stdDev([]float64{5}))
+	assert.InDelta(t, 2.138, stdDev([]float64{2, 4, 4, 4, 5, 5, 7, 9}), 0.001)
+}
+
+func TestPercentile(t *testing.T) {
+	assert.Equal(t, 0.0, percentile(nil, 50))
+	values := []float64{9, 1, 8, 2, 7, 3, 6, 4, 5, 10}
+	assert.Equal(t, 5.0, percentile(values, 50))
+	assert.Equal(t, 9.0, percentile(values, 85))
+	assert.Equal(t, 10.0, percentile(values, 100))
+	assert.Equal(t, 1.0, percentile(values, 0))
+	assert.Equal(t, 9.0, values[0], "input must not be reordered")
+}
@@ -0,0 +1,93 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Status categories used by the flow reports
+const (
+	CategoryToDo       = "todo"
+	CategoryInProgress = "inprogress"
+	CategoryDone       = "done"
+)
+
+// StatusCategoriesCacheName is the cache entry holding the status categories of an instance
+const StatusCategoriesCacheName = "status-categories"
+
+// StatusCategories maps status names, matched case-insensitively, to a status category
+type StatusCategories map[string]string
+
+// NewStatusCategories builds a mapping from lists of status names keyed by category.
+// Category names are case-insensitive and may contain spaces or dashes ("In Progress").
+func NewStatusCategories(byCategory map[string][]string) (StatusCategories, error) {
+	categories := make(StatusCategories)
+	for name, statuses := range byCategory {
+		category := strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+		switch category {
+		case CategoryToDo, CategoryInProgress, CategoryDone:
+		default:
+			return nil, fmt.Errorf("unknown status category %q, expected todo, inprogress or done", name)
+		}
+		for _, status := range statuses {
+			categories[strings.ToLower(status)] = category
+		}
+	}
+	return categories, nil
+}
+
+// Category returns the category of a status. Unmapped statuses are done when they are one
+// of the usual done status names, and to do otherwise.
+func (c StatusCategories) Category(status string) string {
+	if category, ok := c[strings.ToLower(status)]; ok {
+		return category
+	}
+	if isDoneStatus(status) {
+		return CategoryDone
+	}
+	return CategoryToDo
+}
+
+// With returns a copy of the mapping with the overrides applied
+func (c StatusCategories) With(overrides StatusCategories) StatusCategories {
+	merged := make(StatusCategories, len(c)+len(overrides))
+	for status, category := range c {
+		merged[status] = category
+	}
+	for status, category := range overrides {
+		merged[status] = category
+	}
+	return merged
+}
+
+// jiraStatusCategoryKeys maps the keys of Jira's built-in status categories to ours
+var jiraStatusCategoryKeys = map[string]string{
+	"new":           CategoryToDo,
+	"indeterminate": CategoryInProgress,
+	"done":          CategoryDone,
+}
+
+// FetchStatusCategories reads the category Jira assigns to every status of the instance
+func FetchStatusCategories(jiraURL, apikey string) (StatusCategories, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+
+	var statuses []struct {
+		Name           string `json:"name"`
+		StatusCategory struct {
+			Key string `json:"key"`
+		} `json:"statusCategory"`
+	}
+	if err := getJSON(apikey, jiraURL+"/rest/api/2/status", &statuses); err != nil {
+		return nil, fmt.Errorf("fetching statuses: %w", err)
+	}
+
+	categories := make(StatusCategories, len(statuses))
+	for _, s := range statuses {
+		if category, ok := jiraStatusCategoryKeys[s.StatusCategory.Key]; ok {
+			categories[strings.ToLower(s.Name)] = category
+		}
+	}
+	return categories, nil
+}
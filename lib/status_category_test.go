@@ -0,0 +1,59 @@
+package lib
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewStatusCategories(t *testing.T) {
+	categories, err := NewStatusCategories(map[string][]string{
+		"In Progress": {"Coding", "Review"},
+		"done":        {"Shipped"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, CategoryInProgress, categories.Category("coding"))
+	assert.Equal(t, CategoryInProgress, categories.Category("REVIEW"))
+	assert.Equal(t, CategoryDone, categories.Category("Shipped"))
+	// Unmapped statuses fall back to the usual done names
+	assert.Equal(t, CategoryDone, categories.Category("Closed"))
+	assert.Equal(t, CategoryToDo, categories.Category("Backlog"))
+
+	_, err = NewStatusCategories(map[string][]string{"doing": {"Coding"}})
+	assert.Error(t, err)
+}
+
+func TestStatusCategoriesWith(t *testing.T) {
+	base := StatusCategories{"review": CategoryToDo, "coding": CategoryInProgress}
+	merged := base.With(StatusCategories{"review": CategoryInProgress})
+	assert.Equal(t, CategoryInProgress, merged.Category("Review"))
+	assert.Equal(t, CategoryInProgress, merged.Category("Coding"))
+	assert.Equal(t, CategoryToDo, base.Category("Review"), "the original mapping must not change")
+
+	var empty StatusCategories
+	assert.Equal(t, CategoryInProgress, empty.With(base).Category("Coding"))
+}
+
+func TestFetchStatusCategories(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/status", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[
+			{"name": "Open", "statusCategory": {"key": "new"}},
+			{"name": "Code Review", "statusCategory": {"key": "indeterminate"}},
+			{"name": "Closed", "statusCategory": {"key": "done"}},
+			{"name": "Odd", "statusCategory": {"key": "undefined"}}
+		]`))
+	}))
+	defer server.Close()
+
+	categories, err := FetchStatusCategories(server.URL, "token")
+	assert.NoError(t, err)
+	assert.Len(t, categories, 3)
+	assert.Equal(t, CategoryInProgress, categories.Category("Code Review"))
+
+	_, err = FetchStatusCategories("", "token")
+	assert.Error(t, err)
+}
@@ -0,0 +1,217 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Time-in-status measures
+const (
+	MeasureCalendar = "calendar"
+	MeasureBusiness = "business"
+)
+
+// DefaultWorkdayStart is the time of day business hours start
+const DefaultWorkdayStart = 9 * time.Hour
+
+// StatusPeriod is one continuous stay of an issue in a status
+type StatusPeriod struct {
+	Status string    `json:"status" yaml:"status"`
+	Start  time.Time `json:"start" yaml:"start"`
+	End    time.Time `json:"end" yaml:"end"`
+}
+
+// StatusPeriods replays the status changes of an issue into the periods it spent in each
+// status, from its creation until now. The status before the first change is taken from
+// that change; an issue whose status never changed spent its whole life in its current status.
+func StatusPeriods(issue Issue, now time.Time) []StatusPeriod {
+	created, err := parseJiraTime(issue.Created)
+	if err != nil {
+		return nil
+	}
+
+	changes := fieldChanges(issue, "status")
+	if len(changes) == 0 {
+		return []StatusPeriod{{Status: issue.Status.Name, Start: created, End: now}}
+	}
+
+	periods := []StatusPeriod{{Status: changes[0].From, Start: created}}
+	for _, c := range changes {
+		periods[len(periods)-1].End = c.At
+		periods = append(periods, StatusPeriod{Status: c.To, Start: c.At})
+	}
+	periods[len(periods)-1].End = now
+	return periods
+}
+
+// BusinessDuration returns the part of the interval that falls within business hours in the
+// configured timezone. A working day starts at workdayStart and lasts the working hours per
+// day set with SetWorkingTime; the working days of the week start on Monday.
+func BusinessDuration(start, end time.Time, workdayStart time.Duration) time.Duration {
+	if !end.After(start) {
+		return 0
+	}
+
+	hours, days := GetWorkingTime()
+	length := time.Duration(hours * float64(time.Hour))
+	loc := GetTimezone()
+
+	var total time.Duration
+	for day := startOfDay(start.In(loc)); day.Before(end); day = day.AddDate(0, 0, 1) {
+		// Days since Monday
+		if weekday := (int(day.Weekday()) + 6) % 7; float64(weekday) >= days {
+			continue
+		}
+		from := day.Add(workdayStart)
+		to := from.Add(length)
+		if from.Before(start) {
+			from = start
+		}
+		if to.After(end) {
+			to = end
+		}
+		if to.After(from) {
+			total += to.Sub(from)
+		}
+	}
+	return total
+}
+
+// StatusDuration is the total time an issue spent in one status
+type StatusDuration struct {
+	Status string  `json:"status" yaml:"status"`
+	Hours  float64 `json:"hours" yaml:"hours"`
+	Visits int     `json:"visits" yaml:"visits"`
+}
+
+// IssueStatusTimes lists the time an issue spent in each status, in order of first entry
+type IssueStatusTimes struct {
+	Key      string           `json:"key" yaml:"key"`
+	Summary  string           `json:"summary" yaml:"summary"`
+	Status   string           `json:"status" yaml:"status"`
+	Statuses []StatusDuration `json:"statuses" yaml:"statuses"`
+}
+
+// StatusTimeStats aggregates the time spent in one status by the issues that entered it
+type StatusTimeStats struct {
+	Status string  `json:"status" yaml:"status"`
+	Issues int     `json:"issues" yaml:"issues"`
+	Median float64 `json:"medianHours" yaml:"medianHours"`
+	P85    float64 `json:"p85Hours" yaml:"p85Hours"`
+	Max    float64 `json:"maxHours" yaml:"maxHours"`
+}
+
+// TimeInStatusReport holds the time each issue spent in each status and the aggregate per status.
+// Measure is MeasureCalendar or MeasureBusiness.
+type TimeInStatusReport struct {
+	JQL      string             `json:"jql" yaml:"jql"`
+	Measure  string             `json:"measure" yaml:"measure"`
+	Issues   []IssueStatusTimes `json:"issues" yaml:"issues"`
+	Statuses []StatusTimeStats  `json:"statuses" yaml:"statuses"`
+}
+
+// BuildTimeInStatusReport computes the time each issue spent in each status up to now, in
+// calendar time or, when business is set, in business hours starting at workdayStart.
+// Aggregate statuses are listed in the order they were first entered across the issues.
+func BuildTimeInStatusReport(issues []Issue, now time.Time, business bool, workdayStart time.Duration) *TimeInStatusReport {
+	report := &TimeInStatusReport{Measure: MeasureCalendar}
+	measure := func(p StatusPeriod) time.Duration { return p.End.Sub(p.Start) }
+	if business {
+		report.Measure = MeasureBusiness
+		measure = func(p StatusPeriod) time.Duration { return BusinessDuration(p.Start, p.End, workdayStart) }
+	}
+
+	var order []string
+	samples := make(map[string][]float64)
+	for _, issue := range issues {
+		times := IssueStatusTimes{Key: issue.Key, Summary: issue.Summary, Status: issue.Status.Name}
+		index := make(map[string]int)
+		for _, p := range StatusPeriods(issue, now) {
+			i, ok := index[p.Status]
+			if !ok {
+				i = len(times.Statuses)
+				index[p.Status] = i
+				times.Statuses = append(times.Statuses, StatusDuration{Status: p.Status})
+			}
+			times.Statuses[i].Hours += measure(p).Hours()
+			times.Statuses[i].Visits++
+		}
+
+		for _, s := range times.Statuses {
+			if _, ok := samples[s.Status]; !ok {
+				order = append(order, s.Status)
+			}
+			samples[s.Status] = append(samples[s.Status], s.Hours)
+		}
+		report.Issues = append(report.Issues, times)
+	}
+
+	for _, status := range order {
+		values := samples[status]
+		report.Statuses = append(report.Statuses, StatusTimeStats{
+			Status: status,
+			Issues: len(values),
+			Median: percentile(values, 50),
+			P85:    percentile(values, 85),
+			Max:    percentile(values, 100),
+		})
+	}
+	return report
+}
+
+// FetchTimeInStatus fetches the issues matching the JQL query with their history and
+// computes the time each spent in each status. See BuildTimeInStatusReport.
+func FetchTimeInStatus(jiraURL, apikey, jql string, business bool, workdayStart time.Duration, verbose bool) (*TimeInStatusReport, error) {
+	issues, err := FetchIssuesWithHistory(jiraURL, apikey, jql, verbose)
+	if err != nil {
+		return nil, err
+	}
+	report := BuildTimeInStatusReport(issues, time.Now(), business, workdayStart)
+	report.JQL = jql
+	return report, nil
+}
+
+// formatHours formats a number of hours in the report's measure: 24 hour days for calendar
+// time and working days and weeks for business time
+func (r *TimeInStatusReport) formatHours(hours float64) string {
+	d := time.Duration(hours * float64(time.Hour))
+	if r.Measure == MeasureBusiness {
+		return FormatJiraDuration(d)
+	}
+	return formatCalendarDuration(d)
+}
+
+func printTimeInStatusTable(w *tabwriter.Writer, report *TimeInStatusReport) {
+	fmt.Fprintln(w, "KEY\tSTATUS\tTIME\tVISITS\tSUMMARY")
+	for _, issue := range report.Issues {
+		for _, s := range issue.Statuses {
+			fmt.Fprintf(w, "%s\t%s\t%s\t%d\t%s\n", issue.Key, s.Status, report.formatHours(s.Hours), s.Visits, truncateSummary(issue.Summary))
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "STATUS\tISSUES\tMEDIAN\tP85\tMAX")
+	for _, s := range report.Statuses {
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%s\n", s.Status, s.Issues, report.formatHours(s.Median), report.formatHours(s.P85), report.formatHours(s.Max))
+	}
+}
+
+func writeTimeInStatusMarkdown(b *strings.Builder, report *TimeInStatusReport) {
+	fmt.Fprintf(b, "# Time in status (%s time)\n\n", report.Measure)
+	b.WriteString("| Status | Issues | Median | P85 | Max |\n")
+	b.WriteString("|--------|--------|--------|-----|-----|\n")
+	for _, s := range report.Statuses {
+		fmt.Fprintf(b, "| %s | %d | %s | %s | %s |\n", escapeMarkdown(s.Status), s.Issues,
+			report.formatHours(s.Median), report.formatHours(s.P85), report.formatHours(s.Max))
+	}
+	b.WriteString("\n## Issues\n\n")
+	b.WriteString("| Key | Status | Time | Visits | Summary |\n")
+	b.WriteString("|-----|--------|------|--------|---------|\n")
+	for _, issue := range report.Issues {
+		for _, s := range issue.Statuses {
+			fmt.Fprintf(b, "| %s | %s | %s | %d | %s |\n", issue.Key, escapeMarkdown(s.Status),
+				report.formatHours(s.Hours), s.Visits, escapeMarkdown(issue.Summary))
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func statusTimeTestIssue() Issue {
+	// Created Monday 2024-01-15 at 09:00 UTC
+	return Issue{
+		Key:     "TEST-1",
+		Summary: "Fix login",
+		Created: "2024-01-15T09:00:00Z",
+		Status:  Status{Name: "Done"},
+		History: []HistoryItem{
+			{Created: time.Date(2024, 1, 15, 13, 0, 0, 0, time.UTC), Items: []HistoryChange{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+			{Created: time.Date(2024, 1, 16, 13, 0, 0, 0, time.UTC), Items: []HistoryChange{{Field: "status", FromString: "In Progress", ToString: "Review"}}},
+			{Created: time.Date(2024, 1, 16, 15, 0, 0, 0, time.UTC), Items: []HistoryChange{{Field: "status", FromString: "Review", ToString: "In Progress"}}},
+			{Created: time.Date(2024, 1, 19, 15, 0, 0, 0, time.UTC), Items: []HistoryChange{{Field: "status", FromString: "In Progress", ToString: "Done"}}},
+		},
+	}
+}
+
+func TestStatusPeriods(t *testing.T) {
+	now := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	periods := StatusPeriods(statusTimeTestIssue(), now)
+	assert.Len(t, periods, 5)
+	assert.Equal(t, "To Do", periods[0].Status)
+	assert.Equal(t, 4*time.Hour, periods[0].End.Sub(periods[0].Start))
+	assert.Equal(t, "Done", periods[4].Status)
+	assert.Equal(t, now, periods[4].End)
+
+	unchanged := StatusPeriods(Issue{Created: "2024-01-19T00:00:00Z", Status: Status{Name: "Open"}}, now)
+	assert.Equal(t, []StatusPeriod{{Status: "Open", Start: time.Date(2024, 1, 19, 0, 0, 0, 0, time.UTC), End: now}}, unchanged)
+
+	assert.Nil(t, StatusPeriods(Issue{}, now))
+}
+
+func TestBusinessDuration(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	at := func(day, hour int) time.Time { return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC) }
+	// Within one working day
+	assert.Equal(t, 4*time.Hour, BusinessDuration(at(15, 9), at(15, 13), DefaultWorkdayStart))
+	// Overnight only counts working hours: 13-17 and 9-13
+	assert.Equal(t, 8*time.Hour, BusinessDuration(at(15, 13), at(16, 13), DefaultWorkdayStart))
+	// Friday 15:00 to Monday 11:00 skips the weekend
+	assert.Equal(t, 4*time.Hour, BusinessDuration(at(19, 15), at(22, 11), DefaultWorkdayStart))
+	assert.Equal(t, time.Duration(0), BusinessDuration(at(16, 0), at(15, 0), DefaultWorkdayStart))
+}
+
+func TestBuildTimeInStatusReport(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	now := time.Date(2024, 1, 20, 0, 0, 0, 0, time.UTC)
+	other := Issue{
+		Key:     "TEST-2",
+		Created: "2024-01-18T09:00:00Z",
+		History: []HistoryItem{
+			{Created: time.Date(2024, 1, 18, 10, 0, 0, 0, time.UTC), Items: []HistoryChange{{Field: "status", FromString: "To Do", ToString: "In Progress"}}},
+		},
+	}
+
+	report := BuildTimeInStatusReport([]Issue{statusTimeTestIssue(), other}, now, false, DefaultWorkdayStart)
+	assert.Equal(t, MeasureCalendar, report.Measure)
+	assert.Len(t, report.Issues, 2)
+
+	inProgress := report.Issues[0].Statuses[1]
+	assert.Equal(t, "In Progress", inProgress.Status)
+	assert.Equal(t, 2, inProgress.Visits)
+	assert.Equal(t, 96.0, inProgress.Hours)
+
+	statuses := make([]string, len(report.Statuses))
+	for i, s := range report.Statuses {
+		statuses[i] = s.Status
+	}
+	assert.Equal(t, []string{"To Do", "In Progress", "Review", "Done"}, statuses)
+	assert.Equal(t, 2, report.Statuses[1].Issues)
+	assert.Equal(t, 38.0, report.Statuses[1].Median)
+	assert.Equal(t, 96.0, report.Statuses[1].Max)
+
+	business := BuildTimeInStatusReport([]Issue{statusTimeTestIssue()}, now, true, DefaultWorkdayStart)
+	assert.Equal(t, MeasureBusiness, business.Measure)
+	// Monday 13:00 to Tuesday 13:00 and Tuesday 15:00 to Friday 15:00
+	assert.Equal(t, 8.0+24.0, business.Issues[0].Statuses[1].Hours)
+	assert.Equal(t, "4d", business.formatHours(business.Issues[0].Statuses[1].Hours))
+	assert.Equal(t, "4d", report.formatHours(report.Issues[0].Statuses[1].Hours))
+}
+
+func TestFetchTimeInStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.URL.Path == "/rest/api/2/search":
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"startAt": 0, "maxResults": 100, "total": 1,
+				"issues": []map[string]interface{}{
+					{"key": "TEST-1", "fields": map[string]interface{}{"summary": "Fix login", "created": "2024-01-15T09:00:00.000+0000"}},
+				},
+			})
+		case strings.HasPrefix(r.URL.Path, "/rest/api/2/issue/TEST-1"):
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"changelog": map[string]interface{}{
+					"histories": []map[string]interface{}{
+						{"id": "1", "created": "2024-01-15T13:00:00.000+0000",
+							"items": []map[string]string{{"field": "status", "fromString": "To Do", "toString": "Done"}}},
+					},
+				},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	report, err := FetchTimeInStatus(server.URL, "token", "project = TEST", false, DefaultWorkdayStart, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "project = TEST", report.JQL)
+	assert.Len(t, report.Issues, 1)
+	assert.Equal(t, 4.0, report.Issues[0].Statuses[0].Hours)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
@@ -0,0 +1,275 @@
+package lib
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"math"
+	"os"
+	"strings"
+)
+
+// Chart kinds rendered by writeSVGChart
+const (
+	chartBars         = "bars"
+	chartStackedAreas = "stacked-areas"
+	chartLines        = "lines"
+)
+
+// Chart geometry in pixels
+const (
+	svgWidth        = 800
+	svgHeight       = 400
+	svgMarginLeft   = 60
+	svgMarginRight  = 170
+	svgMarginTop    = 40
+	svgMarginBottom = 70
+	svgYTicks       = 5
+	svgMaxXLabels   = 12
+)
+
+// svgPalette holds the series colors, reused in order when there are more series
+var svgPalette = []string{
+	"#4e79a7", "#f28e2b", "#59a14f", "#e15759", "#76b7b2",
+	"#edc948", "#b07aa1", "#ff9da7", "#9c755f", "#bab0ac",
+}
+
+// svgSeries is one named series of values, one per label. NaN values are missing and are
+// left out of line charts.
+type svgSeries struct {
+	name   string
+	values []float64
+}
+
+// svgChart describes a chart with one x label per data point. Stacked area series are drawn
+// bottom up in the order given.
+type svgChart struct {
+	title  string
+	yLabel string
+	kind   string
+	labels []string
+	series []svgSeries
+}
+
+// PrintSVG renders data as an SVG chart to stdout.
+// Accepts *ThroughputReport, *CFDReport or *BurndownReport.
+func PrintSVG(data interface{}) error {
+	var chart svgChart
+	switch v := data.(type) {
+	case *ThroughputReport:
+		if v != nil {
+			chart = throughputChart(v)
+		}
+	case *CFDReport:
+		if v != nil {
+			chart = cfdChart(v)
+		}
+	case *BurndownReport:
+		if v != nil {
+			chart = burndownChart(v)
+		}
+	default:
+		return fmt.Errorf("unsupported data type for SVG output: %T", data)
+	}
+	return writeSVGChart(os.Stdout, chart)
+}
+
+func throughputChart(report *ThroughputReport) svgChart {
+	chart := svgChart{
+		title:  fmt.Sprintf("Throughput per %s", report.Interval),
+		yLabel: "Resolved issues",
+		kind:   chartBars,
+	}
+	resolved := svgSeries{name: "Resolved"}
+	for _, interval := range report.Intervals {
+		chart.labels = append(chart.labels, interval.Start)
+		resolved.values = append(resolved.values, float64(interval.Count))
+	}
+	chart.series = []svgSeries{resolved}
+	return chart
+}
+
+func cfdChart(report *CFDReport) svgChart {
+	chart := svgChart{
+		title:  fmt.Sprintf("Cumulative flow (%s)", report.DateRange),
+		yLabel: "Issues",
+		kind:   chartStackedAreas,
+	}
+	for _, day := range report.Days {
+		chart.labels = append(chart.labels, day.Date)
+	}
+	// Done statuses come last in the report and are stacked at the bottom
+	for i := len(report.Statuses) - 1; i >= 0; i-- {
+		s := svgSeries{name: report.Statuses[i]}
+		for _, day := range report.Days {
+			s.values = append(s.values, float64(day.Counts[report.Statuses[i]]))
+		}
+		chart.series = append(chart.series, s)
+	}
+	return chart
+}
+
+func burndownChart(report *BurndownReport) svgChart {
+	name := report.Sprint
+	if name == "" {
+		name = report.Version
+	}
+	chart := svgChart{
+		title:  fmt.Sprintf("Burndown of %s", name),
+		yLabel: "Issues",
+		kind:   chartLines,
+	}
+	if report.Unit == BurndownPoints {
+		chart.yLabel = "Story points"
+	}
+	ideal := svgSeries{name: "Ideal"}
+	remaining := svgSeries{name: "Remaining"}
+	scope := svgSeries{name: "Scope"}
+	completed := svgSeries{name: "Completed"}
+	actual := func(v *float64) float64 {
+		if v == nil {
+			return math.NaN()
+		}
+		return *v
+	}
+	for _, day := range report.Days {
+		chart.labels = append(chart.labels, day.Date)
+		ideal.values = append(ideal.values, day.Ideal)
+		remaining.values = append(remaining.values, actual(day.Remaining))
+		scope.values = append(scope.values, actual(day.Scope))
+		completed.values = append(completed.values, actual(day.Completed))
+	}
+	chart.series = []svgSeries{ideal, remaining, scope, completed}
+	return chart
+}
+
+// niceCeil rounds v up to 1, 2 or 5 times a power of ten so the axis ticks are round numbers
+func niceCeil(v float64) float64 {
+	if v <= 0 {
+		return 1
+	}
+	magnitude := math.Pow(10, math.Floor(math.Log10(v)))
+	for _, step := range []float64{1, 2, 5, 10} {
+		if v <= step*magnitude {
+			return step * magnitude
+		}
+	}
+	return 10 * magnitude
+}
+
+// writeSVGChart renders the chart as a standalone SVG document
+func writeSVGChart(w io.Writer, chart svgChart) error {
+	plotWidth := float64(svgWidth - svgMarginLeft - svgMarginRight)
+	plotHeight := float64(svgHeight - svgMarginTop - svgMarginBottom)
+	n := len(chart.labels)
+
+	// Stacked series are drawn on top of the running totals
+	tops := make([][]float64, len(chart.series))
+	largest := 0.0
+	for i, s := range chart.series {
+		tops[i] = make([]float64, n)
+		for j := 0; j < n && j < len(s.values); j++ {
+			tops[i][j] = s.values[j]
+			if chart.kind == chartStackedAreas && i > 0 {
+				tops[i][j] += tops[i-1][j]
+			}
+			if !math.IsNaN(tops[i][j]) {
+				largest = math.Max(largest, tops[i][j])
+			}
+		}
+	}
+	yMax := niceCeil(largest)
+
+	x := func(i int) float64 {
+		if chart.kind == chartBars {
+			return svgMarginLeft + (float64(i)+0.5)*plotWidth/float64(n)
+		}
+		if n == 1 {
+			return svgMarginLeft + plotWidth/2
+		}
+		return svgMarginLeft + float64(i)*plotWidth/float64(n-1)
+	}
+	y := func(v float64) float64 {
+		return svgMarginTop + plotHeight - v/yMax*plotHeight
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		svgWidth, svgHeight, svgWidth, svgHeight)
+	fmt.Fprintf(&b, `<rect width="%d" height="%d" fill="white"/>`+"\n", svgWidth, svgHeight)
+	fmt.Fprintf(&b, `<text x="%d" y="%d" font-size="16" font-weight="bold">%s</text>`+"\n",
+		svgMarginLeft, svgMarginTop/2+6, html.EscapeString(chart.title))
+
+	// Y axis with grid lines
+	for i := 0; i <= svgYTicks; i++ {
+		v := yMax * float64(i) / svgYTicks
+		fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="#e0e0e0"/>`+"\n",
+			svgMarginLeft, y(v), svgMarginLeft+plotWidth, y(v))
+		fmt.Fprintf(&b, `<text x="%d" y="%.1f" text-anchor="end">%g</text>`+"\n", svgMarginLeft-6, y(v)+4, v)
+	}
+	fmt.Fprintf(&b, `<text transform="translate(14,%.1f) rotate(-90)" text-anchor="middle">%s</text>`+"\n",
+		svgMarginTop+plotHeight/2, html.EscapeString(chart.yLabel))
+
+	// Series
+	for i, s := range chart.series {
+		color := svgPalette[i%len(svgPalette)]
+		switch chart.kind {
+		case chartBars:
+			barWidth := plotWidth / float64(n) * 0.8
+			for j := range n {
+				fmt.Fprintf(&b, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" fill="%s"><title>%s: %g</title></rect>`+"\n",
+					x(j)-barWidth/2, y(tops[i][j]), barWidth, y(0)-y(tops[i][j]), color,
+					html.EscapeString(chart.labels[j]), tops[i][j])
+			}
+		case chartStackedAreas:
+			var points []string
+			for j := range n {
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(j), y(tops[i][j])))
+			}
+			for j := n - 1; j >= 0; j-- {
+				bottom := 0.0
+				if i > 0 {
+					bottom = tops[i-1][j]
+				}
+				points = append(points, fmt.Sprintf("%.1f,%.1f", x(j), y(bottom)))
+			}
+			fmt.Fprintf(&b, `<polygon points="%s" fill="%s" fill-opacity="0.85"><title>%s</title></polygon>`+"\n",
+				strings.Join(points, " "), color, html.EscapeString(s.name))
+		case chartLines:
+			var points []string
+			for j := range n {
+				if !math.IsNaN(tops[i][j]) {
+					points = append(points, fmt.Sprintf("%.1f,%.1f", x(j), y(tops[i][j])))
+				}
+			}
+			fmt.Fprintf(&b, `<polyline points="%s" fill="none" stroke="%s" stroke-width="2"><title>%s</title></polyline>`+"\n",
+				strings.Join(points, " "), color, html.EscapeString(s.name))
+		}
+	}
+
+	// X axis, labelling at most svgMaxXLabels points
+	fmt.Fprintf(&b, `<line x1="%d" y1="%.1f" x2="%.1f" y2="%.1f" stroke="black"/>`+"\n",
+		svgMarginLeft, y(0), svgMarginLeft+plotWidth, y(0))
+	step := max(1, int(math.Ceil(float64(n)/svgMaxXLabels)))
+	for j := 0; j < n; j += step {
+		fmt.Fprintf(&b, `<text transform="translate(%.1f,%.1f) rotate(-45)" text-anchor="end">%s</text>`+"\n",
+			x(j), y(0)+14, html.EscapeString(chart.labels[j]))
+	}
+
+	// Legend, listing the top of a stack first
+	legendX := svgWidth - svgMarginRight + 16
+	for k := range chart.series {
+		i := k
+		if chart.kind == chartStackedAreas {
+			i = len(chart.series) - 1 - k
+		}
+		legendY := svgMarginTop + k*20
+		fmt.Fprintf(&b, `<rect x="%d" y="%d" width="12" height="12" fill="%s"/>`+"\n",
+			legendX, legendY, svgPalette[i%len(svgPalette)])
+		fmt.Fprintf(&b, `<text x="%d" y="%d">%s</text>`+"\n", legendX+18, legendY+11, html.EscapeString(chart.series[i].name))
+	}
+	b.WriteString("</svg>\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,42 @@
+package lib
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNiceCeil(t *testing.T) {
+	assert.Equal(t, 1.0, niceCeil(0))
+	assert.Equal(t, 1.0, niceCeil(0.7))
+	assert.Equal(t, 5.0, niceCeil(3))
+	assert.Equal(t, 20.0, niceCeil(13))
+	assert.Equal(t, 100.0, niceCeil(100))
+}
+
+func TestWriteSVGChart(t *testing.T) {
+	var b strings.Builder
+	err := writeSVGChart(&b, svgChart{
+		title:  "Flow <A & B>",
+		kind:   chartStackedAreas,
+		labels: []string{"2024-01-01", "2024-01-02"},
+		series: []svgSeries{
+			{name: "Done", values: []float64{1, 2}},
+			{name: "New", values: []float64{3, 3}},
+		},
+	})
+	assert.NoError(t, err)
+
+	svg := b.String()
+	assert.True(t, strings.HasPrefix(svg, "<svg "))
+	assert.True(t, strings.HasSuffix(svg, "</svg>\n"))
+	assert.Contains(t, svg, "Flow &lt;A &amp; B&gt;")
+	assert.Equal(t, 2, strings.Count(svg, "<polygon"))
+	// The stack totals 5, so the axis runs to 5
+	assert.Contains(t, svg, ">5</text>")
+}
+
+func TestPrintSVG_UnsupportedType(t *testing.T) {
+	assert.Error(t, PrintSVG("not a report"))
+}
@@ -0,0 +1,229 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// Team is a named roster of users with an optional default project
+type Team struct {
+	Name    string   `json:"name" yaml:"name"`
+	Project string   `json:"project,omitempty" yaml:"project,omitempty"`
+	Members []string `json:"members" yaml:"members"`
+}
+
+// MemberSummary totals the issues of one team member
+type MemberSummary struct {
+	User        string         `json:"user" yaml:"user"`
+	Issues      int            `json:"issues" yaml:"issues"`
+	StoryPoints float64        `json:"storyPoints" yaml:"storyPoints"`
+	Estimates   EstimateTotals `json:"estimates" yaml:"estimates"`
+}
+
+// TeamSummary totals the issues of each member and of the whole team
+type TeamSummary struct {
+	Members     []MemberSummary `json:"members" yaml:"members"`
+	Issues      int             `json:"issues" yaml:"issues"`
+	StoryPoints float64         `json:"storyPoints" yaml:"storyPoints"`
+	Estimates   EstimateTotals  `json:"estimates" yaml:"estimates"`
+}
+
+// TeamAssignedIssuesResult groups the assigned issues of several users with team totals
+type TeamAssignedIssuesResult struct {
+	Team    string                 `json:"team,omitempty" yaml:"team,omitempty"`
+	Project string                 `json:"project" yaml:"project"`
+	Members []AssignedIssuesResult `json:"members" yaml:"members"`
+	Summary TeamSummary            `json:"summary" yaml:"summary"`
+}
+
+// TeamUserUpdatesResult groups the updated issues of several users with team totals.
+// Team is empty when the users were given individually.
+type TeamUserUpdatesResult struct {
+	Team      string              `json:"team,omitempty" yaml:"team,omitempty"`
+	DateRange string              `json:"dateRange" yaml:"dateRange"`
+	Members   []UserUpdatesResult `json:"members" yaml:"members"`
+	Summary   TeamSummary         `json:"summary" yaml:"summary"`
+}
+
+// Validate reports whether the team has a name and at least one member
+func (t Team) Validate() error {
+	if t.Name == "" {
+		return fmt.Errorf("team name must be provided")
+	}
+	if len(t.Members) == 0 {
+		return fmt.Errorf("team %q has no members", t.Name)
+	}
+	return nil
+}
+
+// summarizeMember totals the issues of one user
+func summarizeMember(user string, issues []Issue) MemberSummary {
+	summary := MemberSummary{User: user, Issues: len(issues), Estimates: SumTimeTracking(issues)}
+	for _, issue := range issues {
+		summary.StoryPoints += issue.StoryPoints
+	}
+	return summary
+}
+
+// summarizeTeam totals the issues of each member; issuesByMember is in member order
+func summarizeTeam(users []string, issuesByMember [][]Issue) TeamSummary {
+	var summary TeamSummary
+	var all []Issue
+	for i, user := range users {
+		member := summarizeMember(user, issuesByMember[i])
+		summary.Members = append(summary.Members, member)
+		summary.Issues += member.Issues
+		summary.StoryPoints += member.StoryPoints
+		all = append(all, issuesByMember[i]...)
+	}
+	summary.Estimates = SumTimeTracking(all)
+	return summary
+}
+
+// SummarizeAssignedIssues totals assigned issues per member and for the team
+func SummarizeAssignedIssues(results []AssignedIssuesResult) TeamSummary {
+	users := make([]string, len(results))
+	issues := make([][]Issue, len(results))
+	for i, r := range results {
+		users[i] = r.User
+		issues[i] = r.Issues
+	}
+	return summarizeTeam(users, issues)
+}
+
+// SummarizeUserUpdates totals updated issues per member and for the team
+func SummarizeUserUpdates(results []UserUpdatesResult) TeamSummary {
+	users := make([]string, len(results))
+	issues := make([][]Issue, len(results))
+	for i, r := range results {
+		users[i] = r.User
+		issues[i] = r.Issues
+	}
+	return summarizeTeam(users, issues)
+}
+
+// FetchTeamAssignedIssues fetches the assigned issues of every team member in the project
+func FetchTeamAssignedIssues(jiraURL, jiraUser, apikey, projectID string, team Team) (*TeamAssignedIssuesResult, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+
+	results, err := FetchAssignedIssuesWithProject(jiraURL, jiraUser, apikey, projectID, team.Members)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamAssignedIssuesResult{
+		Team:    team.Name,
+		Project: projectID,
+		Members: results,
+		Summary: SummarizeAssignedIssues(results),
+	}, nil
+}
+
+// FetchTeamUserUpdates fetches the issues of every team member updated within the date range
+func FetchTeamUserUpdates(jiraURL, jiraUser, apikey string, team Team, startDate, endDate string) (*TeamUserUpdatesResult, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+
+	result, err := FetchMultiUserUpdates(jiraURL, jiraUser, apikey, team.Members, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+	result.Team = team.Name
+	return result, nil
+}
+
+// FetchMultiUserUpdates fetches the issues of several users updated within the date range
+// and totals them per user and overall
+func FetchMultiUserUpdates(jiraURL, jiraUser, apikey string, users []string, startDate, endDate string) (*TeamUserUpdatesResult, error) {
+	dateRange, err := ParseDateRange(startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	results, err := FetchUsersIssuesInDateRange(jiraURL, jiraUser, apikey, users, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	return &TeamUserUpdatesResult{
+		DateRange: dateRange.String(),
+		Members:   results,
+		Summary:   SummarizeUserUpdates(results),
+	}, nil
+}
+
+// userFetchConcurrency is the number of users whose issues are fetched at the same time
+const userFetchConcurrency = 4
+
+// FetchUsersIssuesInDateRange fetches the issues of each user updated within the date range
+// concurrently. The requests take turns on the global rate limiter, and results are returned
+// in user order.
+func FetchUsersIssuesInDateRange(jiraURL, jiraUser, apikey string, users []string, dateRange DateRange) ([]UserUpdatesResult, error) {
+	if len(users) == 0 {
+		return nil, fmt.Errorf("at least one user must be provided")
+	}
+	if jiraURL == "" || jiraUser == "" {
+		return nil, fmt.Errorf("jiraURL and jiraUser must be provided")
+	}
+	for _, user := range users {
+		if user == "" {
+			return nil, fmt.Errorf("users must not be empty")
+		}
+	}
+
+	rl := GetGlobalRateLimiter()
+	results := make([]UserUpdatesResult, len(users))
+	errs := make([]error, len(users))
+	sem := make(chan struct{}, userFetchConcurrency)
+	var wg sync.WaitGroup
+	for i, user := range users {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			rl.Wait()
+			result, err := fetchUserIssuesInRange(jiraURL, apikey, user, dateRange)
+			if err != nil {
+				errs[i] = err
+				return
+			}
+			results[i] = *result
+		}()
+	}
+	wg.Wait()
+
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return results, nil
+}
+
+func printTeamSummaryTable(w *tabwriter.Writer, team string, summary TeamSummary) {
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "MEMBER\tISSUES\tPOINTS\tESTIMATE\tSPENT")
+	for _, m := range summary.Members {
+		fmt.Fprintf(w, "%s\t%d\t%g\t%s\t%s\n", m.User, m.Issues, m.StoryPoints, m.Estimates.OriginalEstimate, m.Estimates.TimeSpent)
+	}
+	label := "TEAM TOTAL"
+	if team != "" {
+		label = strings.ToUpper(team) + " TOTAL"
+	}
+	fmt.Fprintf(w, "%s\t%d\t%g\t%s\t%s\n", label, summary.Issues, summary.StoryPoints, summary.Estimates.OriginalEstimate, summary.Estimates.TimeSpent)
+}
+
+func writeTeamSummaryMarkdown(b *strings.Builder, summary TeamSummary) {
+	b.WriteString("## Team totals\n\n")
+	b.WriteString("| Member | Issues | Points | Estimate | Spent |\n")
+	b.WriteString("|--------|--------|--------|----------|-------|\n")
+	for _, m := range summary.Members {
+		fmt.Fprintf(b, "| %s | %d | %g | %s | %s |\n", escapeMarkdown(m.User), m.Issues, m.StoryPoints, m.Estimates.OriginalEstimate, m.Estimates.TimeSpent)
+	}
+	fmt.Fprintf(b, "| **Total** | %d | %g | %s | %s |\n", summary.Issues, summary.StoryPoints, summary.Estimates.OriginalEstimate, summary.Estimates.TimeSpent)
+}
@@ -0,0 +1,113 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func teamTestResult() *TeamAssignedIssuesResult {
+	members := []AssignedIssuesResult{
+		{User: "alice", Issues: []Issue{
+			{Key: "TEST-1", StoryPoints: 3, Votes: 2, TimeTracking: &TimeTracking{OriginalEstimate: "1d", TimeSpent: "2h"}},
+			{Key: "TEST-2", StoryPoints: 5},
+		}},
+		{User: "bob", Issues: []Issue{
+			{Key: "TEST-3", StoryPoints: 2, TimeTracking: &TimeTracking{OriginalEstimate: "4h"}},
+		}},
+	}
+	return &TeamAssignedIssuesResult{
+		Team:    "cnf",
+		Project: "TEST",
+		Members: members,
+		Summary: SummarizeAssignedIssues(members),
+	}
+}
+
+func TestSummarizeAssignedIssues(t *testing.T) {
+	summary := teamTestResult().Summary
+	assert.Len(t, summary.Members, 2)
+	assert.Equal(t, 2, summary.Members[0].Issues)
+	assert.Equal(t, 8.0, summary.Members[0].StoryPoints)
+	assert.Equal(t, "1d", summary.Members[0].Estimates.OriginalEstimate)
+	assert.Equal(t, 3, summary.Issues)
+	assert.Equal(t, 10.0, summary.StoryPoints)
+	assert.Equal(t, "1d 4h", summary.Estimates.OriginalEstimate)
+}
+
+func TestApplyIssueFilter_Team(t *testing.T) {
+	result := teamTestResult()
+	ApplyIssueFilter(result, IssueFilter{MinVotes: 1})
+	assert.Len(t, result.Members[0].Issues, 1)
+	assert.Empty(t, result.Members[1].Issues)
+	assert.Equal(t, 1, result.Summary.Issues)
+	assert.Equal(t, 0, result.Summary.Members[1].Issues)
+}
+
+func TestTeamValidate(t *testing.T) {
+	assert.NoError(t, Team{Name: "cnf", Members: []string{"alice"}}.Validate())
+	assert.Error(t, Team{Name: "cnf"}.Validate())
+	assert.Error(t, Team{Members: []string{"alice"}}.Validate())
+}
+
+func TestPrintTeamResults(t *testing.T) {
+	result := teamTestResult()
+	assert.NoError(t, PrintTable(result))
+	assert.NoError(t, PrintMarkdown(result))
+	assert.NoError(t, PrintCSV(result))
+	assert.NoError(t, PrintIssueTable(result, []string{"key", "points"}))
+
+	updates := &TeamUserUpdatesResult{
+		Team:      "cnf",
+		DateRange: "2025-01-01 to 2025-01-31",
+		Members:   []UserUpdatesResult{{User: "alice", Issues: []Issue{{Key: "TEST-1"}}}},
+	}
+	assert.NoError(t, PrintTable(updates))
+	assert.NoError(t, PrintMarkdown(updates))
+}
+
+func TestFetchUsersIssuesInDateRange_NoUsers(t *testing.T) {
+	results, err := FetchUsersIssuesInDateRange("https://issues.example.com", "user", "token", nil, DateRange{})
+	assert.Error(t, err)
+	assert.Nil(t, results)
+}
+
+func TestFetchMultiUserUpdates(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		keys := []string{"TEST-1"}
+		switch {
+		case strings.Contains(jql, `assignee="bob"`):
+			keys = []string{"TEST-2"}
+		case strings.Contains(jql, `assignee="carol"`):
+			keys = []string{"TEST-3", "TEST-4", "TEST-5"}
+		}
+
+		// Serve two issues per page
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var issues []map[string]interface{}
+		for i := startAt; i < len(keys) && i < startAt+2; i++ {
+			issues = append(issues, map[string]interface{}{"key": keys[i], "fields": map[string]interface{}{"summary": "Work"}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": startAt, "maxResults": 2, "total": len(keys), "issues": issues,
+		})
+	}))
+	defer server.Close()
+
+	result, err := FetchMultiUserUpdates(server.URL, "user", "token", []string{"alice", "bob", "carol"}, "2024-01-01", "2024-01-31")
+	assert.NoError(t, err)
+	assert.Len(t, result.Members, 3)
+	assert.Equal(t, "alice", result.Members[0].User)
+	assert.Equal(t, "TEST-2", result.Members[1].Issues[0].Key)
+	assert.Len(t, result.Members[2].Issues, 3)
+	assert.Equal(t, 3, result.Members[2].TotalCount)
+	assert.Equal(t, 5, result.Summary.Issues)
+	assert.Equal(t, "2024-01-01 to 2024-01-31", result.DateRange)
+}
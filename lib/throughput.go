@@ -0,0 +1,183 @@
+package lib
+
+import (
+	"fmt"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Throughput intervals
+const (
+	IntervalDay   = "day"
+	IntervalWeek  = "week"
+	IntervalMonth = "month"
+)
+
+// intervalStart returns the start of the day, ISO week or month containing t, in t's location
+func intervalStart(t time.Time, interval string) time.Time {
+	day := startOfDay(t)
+	switch interval {
+	case IntervalWeek:
+		return startOfWeek(day)
+	case IntervalMonth:
+		return day.AddDate(0, 0, 1-day.Day())
+	}
+	return day
+}
+
+// nextInterval returns the start of the interval following the one starting at start
+func nextInterval(start time.Time, interval string) time.Time {
+	switch interval {
+	case IntervalWeek:
+		return start.AddDate(0, 0, 7)
+	case IntervalMonth:
+		return start.AddDate(0, 1, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+// ThroughputInterval counts the issues resolved within one interval. Start and End are
+// the first and last day of the interval.
+type ThroughputInterval struct {
+	Start  string   `json:"start" yaml:"start"`
+	End    string   `json:"end" yaml:"end"`
+	Count  int      `json:"count" yaml:"count"`
+	Issues []string `json:"issues" yaml:"issues"`
+}
+
+// ThroughputReport counts resolved issues per day, week or month
+type ThroughputReport struct {
+	JQL       string               `json:"jql" yaml:"jql"`
+	Interval  string               `json:"interval" yaml:"interval"`
+	Total     int                  `json:"total" yaml:"total"`
+	Average   float64              `json:"average" yaml:"average"`
+	Intervals []ThroughputInterval `json:"intervals" yaml:"intervals"`
+}
+
+// BuildThroughputReport counts the issues resolved in each interval of the range, in the
+// configured timezone. Intervals without resolved issues are included so the series has no
+// gaps. A nil dateRange spans from the first to the last resolution.
+func BuildThroughputReport(issues []Issue, interval string, dateRange *DateRange) (*ThroughputReport, error) {
+	switch interval {
+	case IntervalDay, IntervalWeek, IntervalMonth:
+	default:
+		return nil, fmt.Errorf("unknown interval %q, expected day, week or month", interval)
+	}
+
+	loc := GetTimezone()
+	var resolved []Issue
+	var times []time.Time
+	for _, issue := range issues {
+		t, err := parseJiraTime(issue.Resolved)
+		if err != nil || (dateRange != nil && !dateRange.Contains(t)) {
+			continue
+		}
+		resolved = append(resolved, issue)
+		times = append(times, t.In(loc))
+	}
+
+	report := &ThroughputReport{Interval: interval, Total: len(resolved)}
+	var first, last time.Time
+	if dateRange != nil {
+		first, last = dateRange.Start.In(loc), dateRange.End.In(loc).Add(-time.Nanosecond)
+	} else if len(times) > 0 {
+		first, last = times[0], times[0]
+		for _, t := range times {
+			if t.Before(first) {
+				first = t
+			}
+			if t.After(last) {
+				last = t
+			}
+		}
+	} else {
+		return report, nil
+	}
+
+	// Intervals are indexed by their first day
+	index := make(map[string]int)
+	for start := intervalStart(first, interval); !start.After(last); start = nextInterval(start, interval) {
+		index[start.Format("2006-01-02")] = len(report.Intervals)
+		report.Intervals = append(report.Intervals, ThroughputInterval{
+			Start:  start.Format("2006-01-02"),
+			End:    nextInterval(start, interval).AddDate(0, 0, -1).Format("2006-01-02"),
+			Issues: []string{},
+		})
+	}
+	for i, t := range times {
+		bucket := &report.Intervals[index[intervalStart(t, interval).Format("2006-01-02")]]
+		bucket.Count++
+		bucket.Issues = append(bucket.Issues, resolved[i].Key)
+	}
+
+	if len(report.Intervals) > 0 {
+		report.Average = float64(report.Total) / float64(len(report.Intervals))
+	}
+	return report, nil
+}
+
+// FetchThroughputReport counts the issues matching the JQL query resolved per interval.
+// startDate and endDate are optional and accept any form understood by ParseDateRange.
+func FetchThroughputReport(jiraURL, apikey, jql, interval, startDate, endDate string) (*ThroughputReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if jql == "" {
+		return nil, fmt.Errorf("JQL query must not be empty")
+	}
+
+	var dateRange *DateRange
+	if startDate != "" || endDate != "" {
+		if startDate == "" || endDate == "" {
+			return nil, fmt.Errorf("both the start and end dates must be given")
+		}
+		r, err := ParseDateRange(startDate, endDate, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		dateRange = &r
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+	issues, err := searchAllIssues(client, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := BuildThroughputReport(issues, interval, dateRange)
+	if err != nil {
+		return nil, err
+	}
+	report.JQL = jql
+	return report, nil
+}
+
+func printThroughputTable(w *tabwriter.Writer, report *ThroughputReport) {
+	largest := 0
+	for _, interval := range report.Intervals {
+		largest = max(largest, interval.Count)
+	}
+
+	fmt.Fprintln(w, "START\tEND\tRESOLVED")
+	for _, interval := range report.Intervals {
+		fmt.Fprintf(w, "%s\t%s\t%d\t%s\n", interval.Start, interval.End, interval.Count, histogramBar(interval.Count, largest))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Total:\t%d\n", report.Total)
+	fmt.Fprintf(w, "Average per %s:\t%.1f\n", report.Interval, report.Average)
+}
+
+func writeThroughputMarkdown(b *strings.Builder, report *ThroughputReport) {
+	fmt.Fprintf(b, "# Throughput per %s\n\n", report.Interval)
+	b.WriteString("| Start | End | Resolved |\n")
+	b.WriteString("|-------|-----|----------|\n")
+	for _, interval := range report.Intervals {
+		fmt.Fprintf(b, "| %s | %s | %d |\n", interval.Start, interval.End, interval.Count)
+	}
+	fmt.Fprintf(b, "\n- Total: %d\n", report.Total)
+	fmt.Fprintf(b, "- Average per %s: %.1f\n", report.Interval, report.Average)
+}
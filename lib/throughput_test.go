@@ -0,0 +1,56 @@
+package lib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildThroughputReport(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	issues := []Issue{
+		{Key: "TEST-1", Resolved: "2024-01-02T10:00:00Z"},
+		{Key: "TEST-2", Resolved: "2024-01-03T10:00:00Z"},
+		// Nothing is resolved in the week of January 8th
+		{Key: "TEST-3", Resolved: "2024-01-16T10:00:00Z"},
+		{Key: "TEST-4"},
+	}
+
+	report, err := BuildThroughputReport(issues, IntervalWeek, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.Total)
+	assert.Len(t, report.Intervals, 3)
+	assert.Equal(t, "2024-01-01", report.Intervals[0].Start)
+	assert.Equal(t, "2024-01-07", report.Intervals[0].End)
+	assert.Equal(t, []string{"TEST-1", "TEST-2"}, report.Intervals[0].Issues)
+	assert.Equal(t, 0, report.Intervals[1].Count)
+	assert.Equal(t, 1.0, report.Average)
+
+	dateRange, err := ParseDateRange("2024-01-01", "2024-03-31", time.Now())
+	assert.NoError(t, err)
+	monthly, err := BuildThroughputReport(issues, IntervalMonth, &dateRange)
+	assert.NoError(t, err)
+	assert.Len(t, monthly.Intervals, 3)
+	assert.Equal(t, 3, monthly.Intervals[0].Count)
+	assert.Equal(t, "2024-02-29", monthly.Intervals[1].End)
+
+	_, err = BuildThroughputReport(issues, "year", nil)
+	assert.Error(t, err)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+	assert.NoError(t, PrintSVG(report))
+}
+
+func TestFetchThroughputReport_Validation(t *testing.T) {
+	_, err := FetchThroughputReport("", "", "project = TEST", IntervalWeek, "", "")
+	assert.Error(t, err)
+	_, err = FetchThroughputReport("https://jira.example.com", "token", "", IntervalWeek, "", "")
+	assert.Error(t, err)
+	_, err = FetchThroughputReport("https://jira.example.com", "token", "project = TEST", IntervalWeek, "-30d", "")
+	assert.Error(t, err)
+}
@@ -0,0 +1,190 @@
+package lib
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// UserSearchResult lists the users matching a search
+type UserSearchResult struct {
+	Query string `json:"query" yaml:"query"`
+	Users []User `json:"users" yaml:"users"`
+}
+
+// Identifier returns the value identifying the user in JQL: the Cloud account ID when
+// present, otherwise the username
+func (u User) Identifier() string {
+	if u.AccountID != "" {
+		return u.AccountID
+	}
+	if u.Name != "" {
+		return u.Name
+	}
+	return u.Key
+}
+
+// userDisplayName returns the display name of a user, or "Unassigned" for nil
+func userDisplayName(u *User) string {
+	if u == nil {
+		return "Unassigned"
+	}
+	if u.DisplayName != "" {
+		return u.DisplayName
+	}
+	return u.Identifier()
+}
+
+// accountIDPattern matches Jira Cloud account IDs, in the legacy 24 hex digit form or the
+// "<prefix>:<uuid>" form
+var accountIDPattern = regexp.MustCompile(`^([0-9a-f]{24}|[0-9]+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// IsAccountID reports whether s has the form of a Jira Cloud account ID
+func IsAccountID(s string) bool {
+	return accountIDPattern.MatchString(s)
+}
+
+// RecentAssigneesCacheName returns the cache entry holding the recent assignees of a project
+func RecentAssigneesCacheName(projectKey string) string {
+	return "assignees-" + strings.ToUpper(projectKey)
+}
+
+// SearchUsers finds users whose username, name or email starts with query. Jira Cloud
+// rejects the username parameter of Data Center, so a 400 response is retried with query.
+func SearchUsers(jiraURL, apikey, query string, maxResults int) ([]User, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if query == "" {
+		return nil, fmt.Errorf("search query must be provided")
+	}
+	if maxResults <= 0 {
+		maxResults = 50
+	}
+
+	var found []jiraUserJSON
+	searchURL := func(param string) string {
+		return fmt.Sprintf("%s/rest/api/2/user/search?%s=%s&maxResults=%d",
+			jiraURL, param, url.QueryEscape(query), maxResults)
+	}
+	err := getJSON(apikey, searchURL("username"), &found)
+	var apiErr *apiError
+	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusBadRequest {
+		err = getJSON(apikey, searchURL("query"), &found)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("searching users: %w", err)
+	}
+
+	users := make([]User, 0, len(found))
+	for i := range found {
+		users = append(users, *found[i].toUser())
+	}
+	return users, nil
+}
+
+// FetchRecentAssignees returns the distinct assignees of the project's issues updated in
+// the last days days, sorted by display name
+func FetchRecentAssignees(jiraURL, apikey, projectKey string, days int) ([]User, error) {
+	if jiraURL == "" || apikey == "" || projectKey == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, and projectKey must be provided")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	jql := fmt.Sprintf(`project = "%s" AND assignee is not EMPTY AND updated >= -%dd ORDER BY updated DESC`, projectKey, days)
+	issues, _, err := client.Issue.Search(jql, &jira.SearchOptions{MaxResults: 200, Fields: []string{"assignee"}})
+	if err != nil {
+		return nil, fmt.Errorf("executing JQL query: %w", err)
+	}
+
+	seen := make(map[string]bool)
+	var users []User
+	for _, issue := range issues {
+		if issue.Fields == nil || issue.Fields.Assignee == nil {
+			continue
+		}
+		u := convertJiraUser(issue.Fields.Assignee)
+		if seen[u.Name] {
+			continue
+		}
+		seen[u.Name] = true
+		users = append(users, *u)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].DisplayName < users[j].DisplayName })
+	return users, nil
+}
+
+// ResolveUser finds the single user identified by query: the search result whose username,
+// email, key, account ID or display name equals query. Partial matches are never accepted,
+// so a typo cannot resolve to a different person; the error names them instead.
+func ResolveUser(jiraURL, apikey, query string) (*User, error) {
+	users, err := SearchUsers(jiraURL, apikey, query, 20)
+	if err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("no Jira user matches %q", query)
+	}
+
+	var exact []User
+	for _, u := range users {
+		if userMatches(&u, query) {
+			exact = append(exact, u)
+		}
+	}
+	if len(exact) == 1 {
+		return &exact[0], nil
+	}
+
+	candidates := make([]string, 0, len(users))
+	for _, u := range users {
+		candidates = append(candidates, fmt.Sprintf("%s (%s)", u.Identifier(), u.DisplayName))
+	}
+	if len(users) == 1 {
+		return nil, fmt.Errorf("no Jira user is exactly %q; partial match: %s", query, candidates[0])
+	}
+	return nil, fmt.Errorf("%q matches %d Jira users: %s", query, len(users), strings.Join(candidates, ", "))
+}
+
+// ResolveUsers resolves each query with ResolveUser and returns the JQL identifiers of the
+// users, in order. Queries that are Cloud account IDs are returned unchanged.
+func ResolveUsers(jiraURL, apikey string, queries []string) ([]string, error) {
+	rl := GetGlobalRateLimiter()
+	identifiers := make([]string, 0, len(queries))
+	searched := false
+	for _, q := range queries {
+		// Cloud account IDs already identify a user and are not found by the user search
+		if IsAccountID(q) {
+			identifiers = append(identifiers, q)
+			continue
+		}
+		if searched {
+			rl.Wait()
+		}
+		searched = true
+		u, err := ResolveUser(jiraURL, apikey, q)
+		if err != nil {
+			return nil, fmt.Errorf("resolving user %q: %w", q, err)
+		}
+		identifiers = append(identifiers, u.Identifier())
+	}
+	return identifiers, nil
+}
+
+func printUserSearchTable(w *tabwriter.Writer, result *UserSearchResult) {
+	fmt.Fprintln(w, "USERNAME\tDISPLAY NAME\tEMAIL\tACTIVE")
+	for _, u := range result.Users {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%t\n", u.Identifier(), u.DisplayName, u.EmailAddress, u.Active)
+	}
+}
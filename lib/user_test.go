@@ -0,0 +1,137 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSearchUsers(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/user/search", r.URL.Path)
+		assert.Equal(t, "jd", r.URL.Query().Get("username"))
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"name": "jdoe", "displayName": "Jane Doe", "emailAddress": "jdoe@example.com", "active": true},
+		})
+	}))
+	defer server.Close()
+
+	users, err := SearchUsers(server.URL, "test-token", "jd", 10)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "jdoe", users[0].Name)
+	assert.Equal(t, "jdoe@example.com", users[0].EmailAddress)
+}
+
+func TestSearchUsers_Cloud(t *testing.T) {
+	var params []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/user/search", r.URL.Path)
+		// Jira Cloud rejects the username parameter
+		if r.URL.Query().Has("username") {
+			params = append(params, "username")
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorMessages":["The query parameter 'username' is not supported in GDPR strict mode."]}`))
+			return
+		}
+		params = append(params, "query")
+		assert.Contains(t, r.URL.Query().Get("query"), "Jane")
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+			{"accountId": "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", "accountType": "atlassian", "displayName": "Jane Doe", "active": true},
+		})
+	}))
+	defer server.Close()
+
+	users, err := SearchUsers(server.URL, "test-token", "Jane", 10)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"username", "query"}, params)
+	assert.Len(t, users, 1)
+	assert.Equal(t, "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", users[0].Identifier())
+
+	u, err := ResolveUser(server.URL, "test-token", "Jane Doe")
+	assert.NoError(t, err)
+	assert.Equal(t, "557058:f58131cb-b67d-43c7-b30d-6b58d40bd077", u.Identifier())
+}
+
+func TestIsAccountID(t *testing.T) {
+	assert.True(t, IsAccountID("5b10ac8d82e05b22cc7d4ef5"))
+	assert.True(t, IsAccountID("557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"))
+	assert.False(t, IsAccountID("jdoe"))
+	assert.False(t, IsAccountID("jdoe@example.com"))
+}
+
+// userSearchServer answers user searches from a fixed directory keyed by search text
+func userSearchServer(t *testing.T, directory map[string][]map[string]interface{}) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/user/search", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		users := directory[r.URL.Query().Get("username")]
+		if users == nil {
+			users = []map[string]interface{}{}
+		}
+		_ = json.NewEncoder(w).Encode(users)
+	}))
+}
+
+func TestResolveUser(t *testing.T) {
+	server := userSearchServer(t, map[string][]map[string]interface{}{
+		"jdoe@example.com": {{"name": "jdoe", "emailAddress": "jdoe@example.com", "displayName": "Jane Doe"}},
+		"jsmith": {
+			{"name": "jsmith", "displayName": "John Smith"},
+			{"name": "jsmith2", "displayName": "Jack Smith"},
+		},
+		"jdo": {{"name": "jdoe", "emailAddress": "jdoe@example.com", "displayName": "Jane Doe"}},
+		"js": {
+			{"name": "jsmith", "displayName": "John Smith"},
+			{"name": "jsmith2", "displayName": "Jack Smith"},
+		},
+	})
+	defer server.Close()
+
+	user, err := ResolveUser(server.URL, "test-token", "jdoe@example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, "jdoe", user.Identifier())
+
+	// An exact username match wins over prefix matches
+	user, err = ResolveUser(server.URL, "test-token", "jsmith")
+	assert.NoError(t, err)
+	assert.Equal(t, "jsmith", user.Name)
+
+	_, err = ResolveUser(server.URL, "test-token", "js")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "matches 2 Jira users")
+
+	// A single partial match is not accepted
+	_, err = ResolveUser(server.URL, "test-token", "jdo")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "partial match: jdoe (Jane Doe)")
+
+	_, err = ResolveUser(server.URL, "test-token", "nobody")
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "no Jira user matches")
+}
+
+func TestResolveUsers(t *testing.T) {
+	server := userSearchServer(t, map[string][]map[string]interface{}{
+		"jdoe@example.com": {{"name": "jdoe", "emailAddress": "jdoe@example.com"}},
+		"Cloud User":       {{"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Cloud User"}},
+	})
+	defer server.Close()
+
+	ids, err := ResolveUsers(server.URL, "test-token", []string{"jdoe@example.com", "Cloud User"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"jdoe", "5b10ac8d82e05b22cc7d4ef5"}, ids)
+
+	// Account IDs are used as given without a search
+	ids, err = ResolveUsers(server.URL, "test-token", []string{"557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"557058:f58131cb-b67d-43c7-b30d-6b58d40bd077"}, ids)
+
+	_, err = ResolveUsers(server.URL, "test-token", []string{"jdoe@example.com", "typo"})
+	assert.Error(t, err)
+}
@@ -0,0 +1,139 @@
+package lib
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// velocityBarWidth is the width in characters of the longest bar in the velocity chart
+const velocityBarWidth = 40
+
+// VelocitySprint holds the committed and completed story points of one closed sprint
+type VelocitySprint struct {
+	Sprint         Sprint  `json:"sprint" yaml:"sprint"`
+	Committed      float64 `json:"committed" yaml:"committed"`
+	Completed      float64 `json:"completed" yaml:"completed"`
+	RollingAverage float64 `json:"rollingAverage" yaml:"rollingAverage"`
+}
+
+// VelocityReport summarizes committed vs completed story points across closed sprints
+type VelocityReport struct {
+	BoardID          int              `json:"boardId" yaml:"boardId"`
+	Window           int              `json:"window" yaml:"window"`
+	Sprints          []VelocitySprint `json:"sprints" yaml:"sprints"`
+	AverageCommitted float64          `json:"averageCommitted" yaml:"averageCommitted"`
+	AverageCompleted float64          `json:"averageCompleted" yaml:"averageCompleted"`
+	StdDevCompleted  float64          `json:"stdDevCompleted" yaml:"stdDevCompleted"`
+}
+
+// BuildVelocityReport computes velocity statistics from sprint reports ordered oldest first.
+// The rolling average of each sprint covers that sprint and up to window-1 sprints before it.
+func BuildVelocityReport(boardID int, reports []*SprintReport, window int) *VelocityReport {
+	if window < 1 {
+		window = 1
+	}
+
+	report := &VelocityReport{BoardID: boardID, Window: window}
+	var committed, completed []float64
+	for i, r := range reports {
+		committed = append(committed, r.Totals.Committed.Points)
+		completed = append(completed, r.Totals.Completed.Points)
+
+		from := i - window + 1
+		if from < 0 {
+			from = 0
+		}
+		report.Sprints = append(report.Sprints, VelocitySprint{
+			Sprint:         r.Sprint,
+			Committed:      r.Totals.Committed.Points,
+			Completed:      r.Totals.Completed.Points,
+			RollingAverage: mean(completed[from:]),
+		})
+	}
+
+	report.AverageCommitted = mean(committed)
+	report.AverageCompleted = mean(completed)
+	report.StdDevCompleted = stdDev(completed)
+	return report
+}
+
+// FetchVelocityReport builds a velocity report from the last count closed sprints of a board
+func FetchVelocityReport(jiraURL, apikey string, boardID, count, window int, verbose bool) (*VelocityReport, error) {
+	if jiraURL == "" || apikey == "" {
+		return nil, fmt.Errorf("jiraURL and apikey must be provided")
+	}
+	if count < 1 {
+		return nil, fmt.Errorf("sprint count must be at least 1")
+	}
+
+	sprints, err := FetchBoardSprints(jiraURL, apikey, boardID, "closed")
+	if err != nil {
+		return nil, fmt.Errorf("fetching sprints for board %d: %w", boardID, err)
+	}
+
+	now := time.Now()
+	sort.SliceStable(sprints, func(i, j int) bool {
+		return sprintReportEnd(sprints[i], now).Before(sprintReportEnd(sprints[j], now))
+	})
+	if len(sprints) > count {
+		sprints = sprints[len(sprints)-count:]
+	}
+
+	var reports []*SprintReport
+	for _, sprint := range sprints {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Building sprint report for %s...\n", sprint.Name)
+		}
+		r, err := fetchSprintReportFor(jiraURL, apikey, sprint, "", verbose)
+		if err != nil {
+			return nil, err
+		}
+		reports = append(reports, r)
+	}
+
+	return BuildVelocityReport(boardID, reports, window), nil
+}
+
+func printVelocityReportTable(w *tabwriter.Writer, report *VelocityReport) {
+	fmt.Fprintln(w, "SPRINT\tCOMMITTED\tCOMPLETED\tROLLING AVG")
+	for _, s := range report.Sprints {
+		fmt.Fprintf(w, "%s\t%g\t%g\t%.1f\n", s.Sprint.Name, s.Committed, s.Completed, s.RollingAverage)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Average committed:\t%.1f\n", report.AverageCommitted)
+	fmt.Fprintf(w, "Average completed:\t%.1f\n", report.AverageCompleted)
+	fmt.Fprintf(w, "Std deviation:\t%.1f\n", report.StdDevCompleted)
+	fmt.Fprintln(w)
+	for _, line := range velocityChart(report) {
+		fmt.Fprintln(w, line)
+	}
+}
+
+// velocityChart renders committed (=) and completed (#) points as horizontal ASCII bars
+func velocityChart(report *VelocityReport) []string {
+	maxPoints := 0.0
+	for _, s := range report.Sprints {
+		maxPoints = math.Max(maxPoints, math.Max(s.Committed, s.Completed))
+	}
+
+	bar := func(points float64, char string) string {
+		if maxPoints == 0 {
+			return ""
+		}
+		return strings.Repeat(char, int(math.Round(points/maxPoints*velocityBarWidth)))
+	}
+
+	var lines []string
+	for _, s := range report.Sprints {
+		lines = append(lines,
+			fmt.Sprintf("%s\tcommitted\t%s %g", s.Sprint.Name, bar(s.Committed, "="), s.Committed),
+			fmt.Sprintf("\tcompleted\t%s %g", bar(s.Completed, "#"), s.Completed),
+		)
+	}
+	return lines
+}
@@ -0,0 +1,62 @@
+package lib
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func velocityInput(name string, committed, completed float64) *SprintReport {
+	return &SprintReport{
+		Sprint: Sprint{Name: name},
+		Totals: SprintReportTotals{
+			Committed: SprintReportCount{Points: committed},
+			Completed: SprintReportCount{Points: completed},
+		},
+	}
+}
+
+func TestBuildVelocityReport(t *testing.T) {
+	reports := []*SprintReport{
+		velocityInput("Sprint 1", 20, 10),
+		velocityInput("Sprint 2", 20, 20),
+		velocityInput("Sprint 3", 25, 30),
+		velocityInput("Sprint 4", 30, 20),
+	}
+
+	report := BuildVelocityReport(7, reports, 3)
+	assert.Equal(t, 7, report.BoardID)
+	assert.Len(t, report.Sprints, 4)
+	assert.Equal(t, 10.0, report.Sprints[0].RollingAverage)
+	assert.Equal(t, 15.0, report.Sprints[1].RollingAverage)
+	assert.Equal(t, 20.0, report.Sprints[2].RollingAverage)
+	assert.Equal(t, 70.0/3, report.Sprints[3].RollingAverage)
+	assert.Equal(t, 23.75, report.AverageCommitted)
+	assert.Equal(t, 20.0, report.AverageCompleted)
+	assert.InDelta(t, 8.165, report.StdDevCompleted, 0.001)
+}
+
+func TestVelocityChart(t *testing.T) {
+	report := BuildVelocityReport(1, []*SprintReport{velocityInput("Sprint 1", 20, 10)}, 3)
+	lines := velocityChart(report)
+	assert.Len(t, lines, 2)
+	assert.Contains(t, lines[0], "========================================")
+	assert.Contains(t, lines[1], "####################")
+	assert.NotContains(t, lines[1], "#####################")
+}
+
+func TestFetchVelocityReport_InvalidInput(t *testing.T) {
+	report, err := FetchVelocityReport("", "", 1, 6, 3, false)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+
+	report, err = FetchVelocityReport("https://example.com", "token", 1, 0, 3, false)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}
+
+func TestPrintTable_VelocityReport(t *testing.T) {
+	report := BuildVelocityReport(1, []*SprintReport{velocityInput("Sprint 1", 20, 10)}, 3)
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+}
@@ -0,0 +1,123 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"text/tabwriter"
+)
+
+// ProjectVersion represents a version of a project with its release status
+type ProjectVersion struct {
+	ID               string `json:"id" yaml:"id"`
+	Name             string `json:"name" yaml:"name"`
+	Description      string `json:"description,omitempty" yaml:"description,omitempty"`
+	Released         bool   `json:"released" yaml:"released"`
+	Archived         bool   `json:"archived" yaml:"archived"`
+	Overdue          bool   `json:"overdue" yaml:"overdue"`
+	StartDate        string `json:"startDate,omitempty" yaml:"startDate,omitempty"`
+	ReleaseDate      string `json:"releaseDate,omitempty" yaml:"releaseDate,omitempty"`
+	UnresolvedIssues int    `json:"unresolvedIssues" yaml:"unresolvedIssues"`
+}
+
+// ProjectVersionsResult lists the versions of a project
+type ProjectVersionsResult struct {
+	Project  string           `json:"project" yaml:"project"`
+	Versions []ProjectVersion `json:"versions" yaml:"versions"`
+}
+
+// apiError is returned by getJSON when Jira answers with a status other than 200 OK
+type apiError struct {
+	StatusCode int
+	Body       string
+}
+
+func (e *apiError) Error() string {
+	return fmt.Sprintf("jira API returned status %d: %s", e.StatusCode, e.Body)
+}
+
+// getJSON performs an authenticated GET request and decodes the JSON response into v
+func getJSON(apikey, url string, v interface{}) error {
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := getHTTPClient(apikey)
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		bodyBytes, _ := io.ReadAll(resp.Body)
+		return &apiError{StatusCode: resp.StatusCode, Body: string(bodyBytes)}
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// FetchProjectVersions retrieves the versions of a project, including the number of
+// unresolved issues in each version
+func FetchProjectVersions(jiraURL, apikey, projectKey string) (*ProjectVersionsResult, error) {
+	if jiraURL == "" || apikey == "" || projectKey == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, and projectKey must be provided")
+	}
+
+	var versions []struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Released    bool   `json:"released"`
+		Archived    bool   `json:"archived"`
+		Overdue     bool   `json:"overdue"`
+		StartDate   string `json:"startDate"`
+		ReleaseDate string `json:"releaseDate"`
+	}
+	if err := getJSON(apikey, fmt.Sprintf("%s/rest/api/2/project/%s/versions", jiraURL, projectKey), &versions); err != nil {
+		return nil, fmt.Errorf("fetching versions for %s: %w", projectKey, err)
+	}
+
+	result := &ProjectVersionsResult{Project: projectKey}
+	rl := GetGlobalRateLimiter()
+	for i, v := range versions {
+		var count struct {
+			IssuesUnresolvedCount int `json:"issuesUnresolvedCount"`
+		}
+		if err := getJSON(apikey, fmt.Sprintf("%s/rest/api/2/version/%s/unresolvedIssueCount", jiraURL, v.ID), &count); err != nil {
+			return nil, fmt.Errorf("fetching unresolved issue count for %s: %w", v.Name, err)
+		}
+
+		result.Versions = append(result.Versions, ProjectVersion{
+			ID:               v.ID,
+			Name:             v.Name,
+			Description:      v.Description,
+			Released:         v.Released,
+			Archived:         v.Archived,
+			Overdue:          v.Overdue,
+			StartDate:        v.StartDate,
+			ReleaseDate:      v.ReleaseDate,
+			UnresolvedIssues: count.IssuesUnresolvedCount,
+		})
+
+		if i < len(versions)-1 {
+			rl.Wait()
+		}
+	}
+
+	return result, nil
+}
+
+func printProjectVersionsTable(w *tabwriter.Writer, result *ProjectVersionsResult) {
+	fmt.Fprintln(w, "VERSION\tRELEASED\tRELEASE DATE\tUNRESOLVED\tARCHIVED")
+	for _, v := range result.Versions {
+		fmt.Fprintf(w, "%s\t%t\t%s\t%d\t%t\n", v.Name, v.Released, v.ReleaseDate, v.UnresolvedIssues, v.Archived)
+	}
+}
@@ -0,0 +1,46 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFetchProjectVersions(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/rest/api/2/project/TEST/versions":
+			_ = json.NewEncoder(w).Encode([]map[string]interface{}{
+				{"id": "100", "name": "1.0", "released": true, "releaseDate": "2025-01-15"},
+				{"id": "101", "name": "1.1", "released": false},
+			})
+		case "/rest/api/2/version/100/unresolvedIssueCount":
+			_ = json.NewEncoder(w).Encode(map[string]int{"issuesUnresolvedCount": 0})
+		case "/rest/api/2/version/101/unresolvedIssueCount":
+			_ = json.NewEncoder(w).Encode(map[string]int{"issuesUnresolvedCount": 7})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	result, err := FetchProjectVersions(server.URL, "test-token", "TEST")
+	assert.NoError(t, err)
+	assert.Equal(t, "TEST", result.Project)
+	assert.Len(t, result.Versions, 2)
+	assert.True(t, result.Versions[0].Released)
+	assert.Equal(t, "2025-01-15", result.Versions[0].ReleaseDate)
+	assert.Equal(t, 7, result.Versions[1].UnresolvedIssues)
+
+	assert.NoError(t, PrintTable(result))
+}
+
+func TestFetchProjectVersions_EmptyConfig(t *testing.T) {
+	result, err := FetchProjectVersions("", "", "TEST")
+	assert.Error(t, err)
+	assert.Nil(t, result)
+}
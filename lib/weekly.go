@@ -0,0 +1,356 @@
+package lib
+
+import (
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// WeeklyGroup holds the issues of a weekly report in each category
+type WeeklyGroup struct {
+	Completed  []Issue `json:"completed" yaml:"completed"`
+	InProgress []Issue `json:"inProgress" yaml:"inProgress"`
+	Created    []Issue `json:"created" yaml:"created"`
+	Blocked    []Issue `json:"blocked" yaml:"blocked"`
+}
+
+// WeeklyUser is the part of a weekly report belonging to one user. Completed, in progress
+// and blocked issues are grouped by assignee, created issues by reporter.
+type WeeklyUser struct {
+	User        string `json:"user" yaml:"user"`
+	WeeklyGroup `yaml:",inline"`
+}
+
+// WeeklyEpic is the part of a weekly report belonging to one epic. Key is empty for
+// issues without an epic.
+type WeeklyEpic struct {
+	Key         string `json:"key" yaml:"key"`
+	Summary     string `json:"summary" yaml:"summary"`
+	WeeklyGroup `yaml:",inline"`
+}
+
+// WeeklyReport is the data model of the weekly status templates
+type WeeklyReport struct {
+	Team        string    `json:"team,omitempty" yaml:"team,omitempty"`
+	Week        string    `json:"week" yaml:"week"`
+	DateRange   string    `json:"dateRange" yaml:"dateRange"`
+	Start       time.Time `json:"start" yaml:"start"`
+	End         time.Time `json:"end" yaml:"end"`
+	BaseURL     string    `json:"baseUrl" yaml:"baseUrl"`
+	WeeklyGroup `yaml:",inline"`
+	Users       []WeeklyUser `json:"users" yaml:"users"`
+	Epics       []WeeklyEpic `json:"epics" yaml:"epics"`
+}
+
+// weeklyJQL returns the queries selecting each category of a weekly report for the users
+func weeklyJQL(users []string, dateRange DateRange, blockedStatuses []string, flaggedField string) map[string]string {
+	quoted := make([]string, len(users))
+	for i, u := range users {
+		quoted[i] = fmt.Sprintf("%q", u)
+	}
+	in := strings.Join(quoted, ", ")
+
+	queries := map[string]string{
+		"completed":  fmt.Sprintf("assignee in (%s) AND %s ORDER BY resolved ASC", in, dateRange.JQL("resolved")),
+		"inProgress": fmt.Sprintf(`assignee in (%s) AND resolution = Unresolved AND statusCategory = "In Progress" ORDER BY updated DESC`, in),
+		"created":    fmt.Sprintf("reporter in (%s) AND %s ORDER BY created ASC", in, dateRange.JQL("created")),
+	}
+	if clause := blockedClause(blockedStatuses, flaggedField); clause != "" {
+		queries["blocked"] = fmt.Sprintf("assignee in (%s) AND resolution = Unresolved AND (%s) ORDER BY updated DESC", in, clause)
+	}
+	return queries
+}
+
+// BuildWeeklyReport groups the issues of each category per user and per epic. Users are
+// listed in the given order and epics by key, with issues without an epic last.
+func BuildWeeklyReport(users []string, all WeeklyGroup) *WeeklyReport {
+	report := &WeeklyReport{WeeklyGroup: all}
+
+	for _, user := range users {
+		u := WeeklyUser{User: user}
+		u.Completed = filterIssues(all.Completed, func(i Issue) bool { return userMatches(i.Assignee, user) })
+		u.InProgress = filterIssues(all.InProgress, func(i Issue) bool { return userMatches(i.Assignee, user) })
+		u.Created = filterIssues(all.Created, func(i Issue) bool { return userMatches(i.Reporter, user) })
+		u.Blocked = filterIssues(all.Blocked, func(i Issue) bool { return userMatches(i.Assignee, user) })
+		report.Users = append(report.Users, u)
+	}
+
+	epics := make(map[string]*WeeklyEpic)
+	group := func(issues []Issue, add func(*WeeklyEpic, Issue)) {
+		for _, issue := range issues {
+			e, ok := epics[issue.Epic]
+			if !ok {
+				e = &WeeklyEpic{Key: issue.Epic}
+				epics[issue.Epic] = e
+			}
+			add(e, issue)
+		}
+	}
+	group(all.Completed, func(e *WeeklyEpic, i Issue) { e.Completed = append(e.Completed, i) })
+	group(all.InProgress, func(e *WeeklyEpic, i Issue) { e.InProgress = append(e.InProgress, i) })
+	group(all.Created, func(e *WeeklyEpic, i Issue) { e.Created = append(e.Created, i) })
+	group(all.Blocked, func(e *WeeklyEpic, i Issue) { e.Blocked = append(e.Blocked, i) })
+
+	for _, e := range epics {
+		report.Epics = append(report.Epics, *e)
+	}
+	sort.Slice(report.Epics, func(i, j int) bool {
+		a, b := report.Epics[i].Key, report.Epics[j].Key
+		if a == "" || b == "" {
+			return b == ""
+		}
+		return a < b
+	})
+	return report
+}
+
+// filterIssues returns the issues for which keep reports true
+func filterIssues(issues []Issue, keep func(Issue) bool) []Issue {
+	var kept []Issue
+	for _, issue := range issues {
+		if keep(issue) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
+
+// FetchWeeklyReport fetches the issues the users completed, created, have in progress and
+// have blocked for a week, grouped per user and per epic. week accepts any form understood
+// by ParseDateRange, such as 2024-W03 or last-week, and covers that whole period. Blocked
+// issues are selected as for FetchStandup. Epic summaries are looked up best-effort: an epic
+// that cannot be read keeps an empty summary.
+func FetchWeeklyReport(jiraURL, apikey string, users []string, week string, blockedStatuses []string, flaggedField string, verbose bool) (*WeeklyReport, error) {
+	if jiraURL == "" || apikey == "" || len(users) == 0 {
+		return nil, fmt.Errorf("jiraURL, apikey, and users must be provided")
+	}
+
+	dateRange, err := ParseDateRange(week, week, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	rl := GetGlobalRateLimiter()
+	queries := weeklyJQL(users, dateRange, blockedStatuses, flaggedField)
+	results := make(map[string][]Issue)
+	for _, category := range []string{"completed", "inProgress", "created", "blocked"} {
+		jql, ok := queries[category]
+		if !ok {
+			continue
+		}
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching %s issues...\n", category)
+		}
+		rl.Wait()
+		issues, err := searchAllIssues(client, jql)
+		if err != nil {
+			return nil, fmt.Errorf("fetching %s issues: %w", category, err)
+		}
+		results[category] = issues
+	}
+
+	report := BuildWeeklyReport(users, WeeklyGroup{
+		Completed:  results["completed"],
+		InProgress: results["inProgress"],
+		Created:    results["created"],
+		Blocked:    results["blocked"],
+	})
+	report.Week = week
+	report.DateRange = dateRange.String()
+	report.Start = dateRange.Start
+	report.End = dateRange.End
+	report.BaseURL = strings.TrimSuffix(jiraURL, "/")
+
+	// Look up the epic summaries in one query. Jira rejects the whole query when an epic
+	// was deleted or is not visible, so fall back to one query per epic and leave the
+	// summaries that cannot be read empty.
+	var keys []string
+	for _, e := range report.Epics {
+		if e.Key != "" {
+			keys = append(keys, e.Key)
+		}
+	}
+	if len(keys) > 0 {
+		summaries := make(map[string]string, len(keys))
+		rl.Wait()
+		epics, err := searchAllIssues(client, fmt.Sprintf("issuekey in (%s)", strings.Join(keys, ", ")))
+		if err != nil {
+			epics = nil
+			for _, key := range keys {
+				rl.Wait()
+				found, err := searchAllIssues(client, fmt.Sprintf("issuekey = %s", key))
+				if err != nil {
+					fmt.Fprintf(os.Stderr, "Warning: failed to fetch epic %s: %v\n", key, err)
+					continue
+				}
+				epics = append(epics, found...)
+			}
+		}
+		for _, e := range epics {
+			summaries[e.Key] = e.Summary
+		}
+		for i := range report.Epics {
+			report.Epics[i].Summary = summaries[report.Epics[i].Key]
+		}
+	}
+	return report, nil
+}
+
+// weeklyTemplateFuncs returns the functions available to weekly report templates
+func weeklyTemplateFuncs(report *WeeklyReport) map[string]interface{} {
+	return map[string]interface{}{
+		// url returns the browse link of an issue key
+		"url": func(key string) string {
+			return report.BaseURL + "/browse/" + key
+		},
+		// points totals the story points of the issues
+		"points": func(issues []Issue) float64 {
+			var total float64
+			for _, i := range issues {
+				total += i.StoryPoints
+			}
+			return total
+		},
+		// name returns the display name of a user, or "Unassigned"
+		"name": userDisplayName,
+		// date formats a time or Jira timestamp as YYYY-MM-DD in the configured timezone
+		"date": func(v interface{}) string {
+			var t time.Time
+			switch v := v.(type) {
+			case time.Time:
+				t = v
+			case string:
+				parsed, err := parseJiraTime(v)
+				if err != nil {
+					return v
+				}
+				t = parsed
+			}
+			return t.In(GetTimezone()).Format("2006-01-02")
+		},
+	}
+}
+
+// DefaultWeeklyMarkdownTemplate is the built-in Markdown template of the weekly report
+const DefaultWeeklyMarkdownTemplate = `{{define "issues"}}{{range .}}- [{{.Key}}]({{url .Key}}) {{.Summary}} ({{name .Assignee}}, {{.Status.Name}})
+{{else}}- None
+{{end}}{{end -}}
+# Weekly status{{if .Team}}: {{.Team}}{{end}} ({{.Week}})
+
+{{.DateRange}}
+
+| | Issues | Points |
+|---|---|---|
+| Completed | {{len .Completed}} | {{points .Completed}} |
+| In progress | {{len .InProgress}} | {{points .InProgress}} |
+| Created | {{len .Created}} | {{points .Created}} |
+| Blocked | {{len .Blocked}} | {{points .Blocked}} |
+
+## Completed
+
+{{template "issues" .Completed}}
+## In progress
+
+{{template "issues" .InProgress}}
+## Blocked
+
+{{template "issues" .Blocked}}
+## Created
+
+{{template "issues" .Created}}
+## By epic
+{{range .Epics}}
+### {{if .Key}}{{.Key}}{{with .Summary}} {{.}}{{end}}{{else}}No epic{{end}}
+
+{{len .Completed}} completed, {{len .InProgress}} in progress, {{len .Created}} created, {{len .Blocked}} blocked
+{{end}}
+## By person
+{{range .Users}}
+### {{.User}}
+
+{{len .Completed}} completed ({{points .Completed}} points), {{len .InProgress}} in progress, {{len .Created}} created, {{len .Blocked}} blocked
+{{end}}`
+
+// DefaultWeeklyHTMLTemplate is the built-in HTML template of the weekly report
+const DefaultWeeklyHTMLTemplate = `{{define "issues"}}<ul>
+{{range .}}  <li><a href="{{url .Key}}">{{.Key}}</a> {{.Summary}} ({{name .Assignee}}, {{.Status.Name}})</li>
+{{else}}  <li>None</li>
+{{end}}</ul>
+{{end -}}
+<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Weekly status{{if .Team}}: {{.Team}}{{end}} ({{.Week}})</title>
+</head>
+<body>
+<h1>Weekly status{{if .Team}}: {{.Team}}{{end}} ({{.Week}})</h1>
+<p>{{.DateRange}}</p>
+<table>
+<tr><th></th><th>Issues</th><th>Points</th></tr>
+<tr><td>Completed</td><td>{{len .Completed}}</td><td>{{points .Completed}}</td></tr>
+<tr><td>In progress</td><td>{{len .InProgress}}</td><td>{{points .InProgress}}</td></tr>
+<tr><td>Created</td><td>{{len .Created}}</td><td>{{points .Created}}</td></tr>
+<tr><td>Blocked</td><td>{{len .Blocked}}</td><td>{{points .Blocked}}</td></tr>
+</table>
+<h2>Completed</h2>
+{{template "issues" .Completed}}<h2>In progress</h2>
+{{template "issues" .InProgress}}<h2>Blocked</h2>
+{{template "issues" .Blocked}}<h2>Created</h2>
+{{template "issues" .Created}}<h2>By epic</h2>
+<table>
+<tr><th>Epic</th><th>Completed</th><th>In progress</th><th>Created</th><th>Blocked</th></tr>
+{{range .Epics}}<tr><td>{{if .Key}}{{.Key}}{{with .Summary}} {{.}}{{end}}{{else}}No epic{{end}}</td><td>{{len .Completed}}</td><td>{{len .InProgress}}</td><td>{{len .Created}}</td><td>{{len .Blocked}}</td></tr>
+{{end}}</table>
+<h2>By person</h2>
+<table>
+<tr><th>User</th><th>Completed</th><th>Points</th><th>In progress</th><th>Created</th><th>Blocked</th></tr>
+{{range .Users}}<tr><td>{{.User}}</td><td>{{len .Completed}}</td><td>{{points .Completed}}</td><td>{{len .InProgress}}</td><td>{{len .Created}}</td><td>{{len .Blocked}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`
+
+// weeklyTemplate is implemented by both text/template and html/template templates
+type weeklyTemplate interface {
+	Execute(w io.Writer, data interface{}) error
+}
+
+// RenderWeeklyReport renders the report with a Go template. format is "markdown" or "html";
+// HTML templates escape the issue data. An empty text uses the built-in template of the format.
+func RenderWeeklyReport(w io.Writer, report *WeeklyReport, format, text string) error {
+	var tmpl weeklyTemplate
+	var err error
+	switch format {
+	case "markdown", "md":
+		if text == "" {
+			text = DefaultWeeklyMarkdownTemplate
+		}
+		tmpl, err = template.New("weekly").Funcs(weeklyTemplateFuncs(report)).Parse(text)
+	case "html":
+		if text == "" {
+			text = DefaultWeeklyHTMLTemplate
+		}
+		tmpl, err = htmltemplate.New("weekly").Funcs(weeklyTemplateFuncs(report)).Parse(text)
+	default:
+		return fmt.Errorf("unsupported template format: %s", format)
+	}
+	if err != nil {
+		return fmt.Errorf("parsing weekly report template: %w", err)
+	}
+
+	if err := tmpl.Execute(w, report); err != nil {
+		return fmt.Errorf("rendering weekly report: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func weeklyTestGroup() WeeklyGroup {
+	jdoe := &User{Name: "jdoe", DisplayName: "Jane Doe"}
+	jsmith := &User{Name: "jsmith", DisplayName: "John Smith"}
+	return WeeklyGroup{
+		Completed: []Issue{
+			{Key: "TEST-1", Summary: "Fix login", Assignee: jdoe, Epic: "TEST-100", StoryPoints: 3},
+			{Key: "TEST-2", Summary: "Add logout", Assignee: jsmith, StoryPoints: 2},
+		},
+		InProgress: []Issue{
+			{Key: "TEST-3", Summary: "Refactor <auth>", Assignee: jdoe, Epic: "TEST-100"},
+		},
+		Created: []Issue{
+			{Key: "TEST-4", Summary: "New bug", Reporter: jsmith, Epic: "TEST-050"},
+		},
+	}
+}
+
+func TestBuildWeeklyReport(t *testing.T) {
+	report := BuildWeeklyReport([]string{"jdoe", "jsmith"}, weeklyTestGroup())
+
+	assert.Len(t, report.Completed, 2)
+	assert.Len(t, report.Users, 2)
+	assert.Len(t, report.Users[0].Completed, 1)
+	assert.Len(t, report.Users[0].InProgress, 1)
+	assert.Empty(t, report.Users[0].Created)
+	assert.Len(t, report.Users[1].Created, 1)
+
+	keys := make([]string, len(report.Epics))
+	for i, e := range report.Epics {
+		keys[i] = e.Key
+	}
+	assert.Equal(t, []string{"TEST-050", "TEST-100", ""}, keys)
+	assert.Len(t, report.Epics[1].Completed, 1)
+	assert.Len(t, report.Epics[1].InProgress, 1)
+}
+
+func TestWeeklyJQL(t *testing.T) {
+	SetTimezone(time.UTC)
+	defer SetTimezone(nil)
+
+	dateRange, err := ParseDateRange("2024-W03", "2024-W03", time.Now())
+	assert.NoError(t, err)
+
+	queries := weeklyJQL([]string{"jdoe", "jsmith"}, dateRange, []string{"Blocked"}, "")
+	assert.Contains(t, queries["completed"], `assignee in ("jdoe", "jsmith")`)
+	assert.Contains(t, queries["completed"], `resolved >= "2024-01-15 00:00"`)
+	assert.Contains(t, queries["inProgress"], `statusCategory = "In Progress"`)
+	assert.Contains(t, queries["created"], `reporter in ("jdoe", "jsmith")`)
+	assert.Contains(t, queries["blocked"], `status in ("Blocked")`)
+
+	_, ok := weeklyJQL([]string{"jdoe"}, dateRange, nil, "")["blocked"]
+	assert.False(t, ok)
+}
+
+func TestRenderWeeklyReport(t *testing.T) {
+	report := BuildWeeklyReport([]string{"jdoe", "jsmith"}, weeklyTestGroup())
+	report.Team = "cnf"
+	report.Week = "2024-W03"
+	report.BaseURL = "https://jira.example.com"
+
+	var md strings.Builder
+	assert.NoError(t, RenderWeeklyReport(&md, report, "markdown", ""))
+	assert.Contains(t, md.String(), "# Weekly status: cnf (2024-W03)")
+	assert.Contains(t, md.String(), "| Completed | 2 | 5 |")
+	assert.Contains(t, md.String(), "- [TEST-1](https://jira.example.com/browse/TEST-1) Fix login (Jane Doe")
+	assert.Contains(t, md.String(), "### No epic")
+
+	var html strings.Builder
+	assert.NoError(t, RenderWeeklyReport(&html, report, "html", ""))
+	assert.Contains(t, html.String(), `<a href="https://jira.example.com/browse/TEST-1">TEST-1</a>`)
+	assert.Contains(t, html.String(), "Refactor &lt;auth&gt;")
+
+	var custom strings.Builder
+	assert.NoError(t, RenderWeeklyReport(&custom, report, "markdown", `{{range .Users}}{{.User}}={{len .Completed}} {{end}}`))
+	assert.Equal(t, "jdoe=1 jsmith=1 ", custom.String())
+
+	assert.Error(t, RenderWeeklyReport(&custom, report, "markdown", "{{.Missing"))
+	assert.Error(t, RenderWeeklyReport(&custom, report, "pdf", ""))
+}
+
+func TestFetchWeeklyReport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		jql := r.URL.Query().Get("jql")
+		var issues []map[string]interface{}
+		switch {
+		case strings.HasPrefix(jql, "issuekey in"):
+			issues = []map[string]interface{}{{"key": "TEST-100", "fields": map[string]interface{}{"summary": "Auth epic"}}}
+		case strings.Contains(jql, "resolved >="):
+			issues = []map[string]interface{}{{"key": "TEST-1", "fields": map[string]interface{}{
+				"summary":               "Fix login",
+				"assignee":              map[string]string{"name": "jdoe"},
+				DefaultEpicLinkField:    "TEST-100",
+				DefaultStoryPointsField: 3,
+			}}}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 0, "maxResults": 100, "total": len(issues), "issues": issues,
+		})
+	}))
+	defer server.Close()
+
+	report, err := FetchWeeklyReport(server.URL, "token", []string{"jdoe"}, "2024-W03", DefaultBlockedStatuses, DefaultFlaggedField, false)
+	assert.NoError(t, err)
+	assert.Equal(t, "2024-W03", report.Week)
+	assert.Equal(t, server.URL, report.BaseURL)
+	assert.Len(t, report.Completed, 1)
+	assert.Equal(t, "TEST-100", report.Completed[0].Epic)
+	assert.Len(t, report.Users[0].Completed, 1)
+	assert.Len(t, report.Epics, 1)
+	assert.Equal(t, "Auth epic", report.Epics[0].Summary)
+
+	assert.NoError(t, PrintMarkdown(report))
+}
+
+func TestFetchWeeklyReport_MissingEpic(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		if strings.HasPrefix(jql, "issuekey in") || jql == "issuekey = TEST-404" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte(`{"errorMessages":["An issue with key 'TEST-404' does not exist"]}`))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		var issues []map[string]interface{}
+		switch {
+		case jql == "issuekey = TEST-100":
+			issues = []map[string]interface{}{{"key": "TEST-100", "fields": map[string]interface{}{"summary": "Auth epic"}}}
+		case strings.Contains(jql, "resolved >="):
+			issues = []map[string]interface{}{
+				{"key": "TEST-1", "fields": map[string]interface{}{"assignee": map[string]string{"name": "jdoe"}, DefaultEpicLinkField: "TEST-100"}},
+				{"key": "TEST-2", "fields": map[string]interface{}{"assignee": map[string]string{"name": "jdoe"}, DefaultEpicLinkField: "TEST-404"}},
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 0, "maxResults": 100, "total": len(issues), "issues": issues,
+		})
+	}))
+	defer server.Close()
+
+	report, err := FetchWeeklyReport(server.URL, "token", []string{"jdoe"}, "2024-W03", DefaultBlockedStatuses, DefaultFlaggedField, false)
+	assert.NoError(t, err)
+	assert.Len(t, report.Epics, 2)
+	assert.Equal(t, "TEST-100", report.Epics[0].Key)
+	assert.Equal(t, "Auth epic", report.Epics[0].Summary)
+	assert.Equal(t, "TEST-404", report.Epics[1].Key)
+	assert.Empty(t, report.Epics[1].Summary)
+}
@@ -0,0 +1,249 @@
+package lib
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+)
+
+// Workload flags, set for members above the team mean by more than the allowed deviation
+const (
+	WorkloadFlagOpen      = "open"
+	WorkloadFlagWIP       = "wip"
+	WorkloadFlagRemaining = "remaining"
+)
+
+// DefaultWorkloadDeviation is the share above the team mean at which a member is flagged
+const DefaultWorkloadDeviation = 0.5
+
+// WorkloadMember is the open work assigned to one team member. WIP counts the open issues
+// in a status of the in progress category.
+type WorkloadMember struct {
+	User                     string         `json:"user" yaml:"user"`
+	Open                     int            `json:"open" yaml:"open"`
+	WIP                      int            `json:"wip" yaml:"wip"`
+	RemainingEstimate        string         `json:"remainingEstimate" yaml:"remainingEstimate"`
+	RemainingEstimateSeconds int            `json:"remainingEstimateSeconds" yaml:"remainingEstimateSeconds"`
+	ByPriority               map[string]int `json:"byPriority" yaml:"byPriority"`
+	ByType                   map[string]int `json:"byType" yaml:"byType"`
+	Flags                    []string       `json:"flags" yaml:"flags"`
+}
+
+// WorkloadMean is the mean open work per team member
+type WorkloadMean struct {
+	Open                     float64 `json:"open" yaml:"open"`
+	WIP                      float64 `json:"wip" yaml:"wip"`
+	RemainingEstimateSeconds float64 `json:"remainingEstimateSeconds" yaml:"remainingEstimateSeconds"`
+}
+
+// WorkloadReport compares the open work of the members of a team. Priorities and IssueTypes
+// list the values found, most common first.
+type WorkloadReport struct {
+	Team       string           `json:"team,omitempty" yaml:"team,omitempty"`
+	Project    string           `json:"project" yaml:"project"`
+	Deviation  float64          `json:"deviation" yaml:"deviation"`
+	Mean       WorkloadMean     `json:"mean" yaml:"mean"`
+	Flagged    int              `json:"flagged" yaml:"flagged"`
+	Priorities []string         `json:"priorities" yaml:"priorities"`
+	IssueTypes []string         `json:"issueTypes" yaml:"issueTypes"`
+	Members    []WorkloadMember `json:"members" yaml:"members"`
+}
+
+// workloadName returns the name of a priority or issue type, or "None" when it is empty
+func workloadName(name string) string {
+	if name == "" {
+		return "None"
+	}
+	return name
+}
+
+// sortedByCount returns the keys of the counts, highest count first and then by name
+func sortedByCount(counts map[string]int) []string {
+	names := make([]string, 0, len(counts))
+	for name := range counts {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		if counts[names[i]] != counts[names[j]] {
+			return counts[names[i]] > counts[names[j]]
+		}
+		return names[i] < names[j]
+	})
+	return names
+}
+
+// BuildWorkloadReport totals the open issues of each member. Issues that are resolved or in
+// a status of the done category are not open. A member is flagged for each of the open
+// issues, WIP and remaining estimate exceeding the team mean by more than the deviation,
+// a share of the mean such as 0.5 for 50%.
+func BuildWorkloadReport(results []AssignedIssuesResult, categories StatusCategories, deviation float64) *WorkloadReport {
+	report := &WorkloadReport{Deviation: deviation, Members: []WorkloadMember{}}
+	priorities, types := map[string]int{}, map[string]int{}
+
+	for _, r := range results {
+		member := WorkloadMember{User: r.User, ByPriority: map[string]int{}, ByType: map[string]int{}, Flags: []string{}}
+		var remaining time.Duration
+		for _, issue := range r.Issues {
+			category := categories.Category(issue.Status.Name)
+			if issue.Resolved != "" || category == CategoryDone {
+				continue
+			}
+			member.Open++
+			if category == CategoryInProgress {
+				member.WIP++
+			}
+			remaining += issue.TimeTracking.RemainingEstimateDuration()
+			member.ByPriority[workloadName(issue.Priority.Name)]++
+			member.ByType[workloadName(issue.IssueType.Name)]++
+			priorities[workloadName(issue.Priority.Name)]++
+			types[workloadName(issue.IssueType.Name)]++
+		}
+		member.RemainingEstimate = FormatJiraDuration(remaining)
+		member.RemainingEstimateSeconds = int(remaining.Seconds())
+		report.Members = append(report.Members, member)
+	}
+	report.Priorities = sortedByCount(priorities)
+	report.IssueTypes = sortedByCount(types)
+
+	if len(report.Members) == 0 {
+		return report
+	}
+	for _, m := range report.Members {
+		report.Mean.Open += float64(m.Open)
+		report.Mean.WIP += float64(m.WIP)
+		report.Mean.RemainingEstimateSeconds += float64(m.RemainingEstimateSeconds)
+	}
+	n := float64(len(report.Members))
+	report.Mean.Open /= n
+	report.Mean.WIP /= n
+	report.Mean.RemainingEstimateSeconds /= n
+
+	above := func(value, mean float64) bool { return mean > 0 && value > mean*(1+deviation) }
+	for i := range report.Members {
+		m := &report.Members[i]
+		if above(float64(m.Open), report.Mean.Open) {
+			m.Flags = append(m.Flags, WorkloadFlagOpen)
+		}
+		if above(float64(m.WIP), report.Mean.WIP) {
+			m.Flags = append(m.Flags, WorkloadFlagWIP)
+		}
+		if above(float64(m.RemainingEstimateSeconds), report.Mean.RemainingEstimateSeconds) {
+			m.Flags = append(m.Flags, WorkloadFlagRemaining)
+		}
+		if len(m.Flags) > 0 {
+			report.Flagged++
+		}
+	}
+	return report
+}
+
+// FetchTeamWorkload fetches every unresolved issue assigned to each team member in the
+// project and builds their workload. See BuildWorkloadReport.
+func FetchTeamWorkload(jiraURL, jiraUser, apikey, projectID string, team Team, categories StatusCategories, deviation float64) (*WorkloadReport, error) {
+	if err := team.Validate(); err != nil {
+		return nil, err
+	}
+	if jiraURL == "" || apikey == "" || projectID == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, and projectID must be provided")
+	}
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+	rl := GetGlobalRateLimiter()
+	results := make([]AssignedIssuesResult, 0, len(team.Members))
+	for _, member := range team.Members {
+		rl.Wait()
+		jql := fmt.Sprintf("project = %s AND assignee = \"%s\" AND resolution = Unresolved ORDER BY created DESC", projectID, member)
+		issues, err := searchAllIssues(client, jql)
+		if err != nil {
+			return nil, fmt.Errorf("fetching issues for %s: %w", member, err)
+		}
+		results = append(results, AssignedIssuesResult{User: member, Issues: issues})
+	}
+
+	report := BuildWorkloadReport(results, categories, deviation)
+	report.Team = team.Name
+	report.Project = projectID
+	return report, nil
+}
+
+// formatWorkloadMeanRemaining formats the mean remaining estimate as a Jira duration
+func formatWorkloadMeanRemaining(mean WorkloadMean) string {
+	return FormatJiraDuration(time.Duration(mean.RemainingEstimateSeconds) * time.Second)
+}
+
+func printWorkloadTable(w *tabwriter.Writer, report *WorkloadReport) {
+	fmt.Fprint(w, "USER\tOPEN\tWIP\tREMAINING\t")
+	for _, p := range report.Priorities {
+		fmt.Fprintf(w, "%s\t", strings.ToUpper(p))
+	}
+	fmt.Fprintln(w, "FLAGS")
+	for _, m := range report.Members {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%s\t", m.User, m.Open, m.WIP, m.RemainingEstimate)
+		for _, p := range report.Priorities {
+			fmt.Fprintf(w, "%d\t", m.ByPriority[p])
+		}
+		fmt.Fprintln(w, formatAgingFlags(m.Flags))
+	}
+	fmt.Fprintf(w, "Team mean\t%.1f\t%.1f\t%s\n", report.Mean.Open, report.Mean.WIP, formatWorkloadMeanRemaining(report.Mean))
+
+	if len(report.IssueTypes) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "USER\t%s\n", strings.ToUpper(strings.Join(report.IssueTypes, "\t")))
+		for _, m := range report.Members {
+			fmt.Fprint(w, m.User)
+			for _, t := range report.IssueTypes {
+				fmt.Fprintf(w, "\t%d", m.ByType[t])
+			}
+			fmt.Fprintln(w)
+		}
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "Flagged:\t%d of %d (more than %.0f%% above the team mean)\n", report.Flagged, len(report.Members), report.Deviation*100)
+}
+
+func writeWorkloadMarkdown(b *strings.Builder, report *WorkloadReport) {
+	b.WriteString("# Workload")
+	if report.Team != "" {
+		fmt.Fprintf(b, " of %s", escapeMarkdown(report.Team))
+	}
+	b.WriteString("\n\n")
+	fmt.Fprintf(b, "%d of %d members more than %.0f%% above the team mean of %.1f open issues, %.1f in progress and %s remaining.\n\n",
+		report.Flagged, len(report.Members), report.Deviation*100, report.Mean.Open, report.Mean.WIP, formatWorkloadMeanRemaining(report.Mean))
+
+	b.WriteString("| User | Open | WIP | Remaining |")
+	separator := "|------|------|-----|-----------|"
+	for _, p := range report.Priorities {
+		fmt.Fprintf(b, " %s |", escapeMarkdown(p))
+		separator += "---|"
+	}
+	b.WriteString(" Flags |\n" + separator + "-------|\n")
+	for _, m := range report.Members {
+		fmt.Fprintf(b, "| %s | %d | %d | %s |", escapeMarkdown(m.User), m.Open, m.WIP, m.RemainingEstimate)
+		for _, p := range report.Priorities {
+			fmt.Fprintf(b, " %d |", m.ByPriority[p])
+		}
+		fmt.Fprintf(b, " %s |\n", formatAgingFlags(m.Flags))
+	}
+
+	if len(report.IssueTypes) > 0 {
+		b.WriteString("\n## By issue type\n\n| User |")
+		separator = "|------|"
+		for _, t := range report.IssueTypes {
+			fmt.Fprintf(b, " %s |", escapeMarkdown(t))
+			separator += "---|"
+		}
+		b.WriteString("\n" + separator + "\n")
+		for _, m := range report.Members {
+			fmt.Fprintf(b, "| %s |", escapeMarkdown(m.User))
+			for _, t := range report.IssueTypes {
+				fmt.Fprintf(b, " %d |", m.ByType[t])
+			}
+			b.WriteString("\n")
+		}
+	}
+}
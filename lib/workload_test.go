@@ -0,0 +1,115 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildWorkloadReport(t *testing.T) {
+	issue := func(status, priority, issueType string, remainingSeconds int) Issue {
+		return Issue{
+			Status: Status{Name: status}, Priority: Priority{Name: priority}, IssueType: IssueType{Name: issueType},
+			TimeTracking: &TimeTracking{RemainingEstimateSeconds: remainingSeconds},
+		}
+	}
+	resolved := issue("Coding", "Major", "Bug", 3600)
+	resolved.Resolved = "2024-01-05T00:00:00Z"
+
+	results := []AssignedIssuesResult{
+		{User: "alice", Issues: []Issue{
+			issue("Coding", "Major", "Bug", 8*3600),
+			issue("Review", "Critical", "Bug", 4*3600),
+			issue("New", "Major", "Story", 0),
+			issue("New", "Major", "Story", 0),
+			issue("Closed", "Major", "Story", 3600),
+			resolved,
+		}},
+		{User: "bob", Issues: []Issue{issue("New", "", "Task", 3600)}},
+		{User: "carol"},
+	}
+	categories := StatusCategories{"coding": CategoryInProgress, "review": CategoryInProgress}
+
+	report := BuildWorkloadReport(results, categories, DefaultWorkloadDeviation)
+	assert.Len(t, report.Members, 3)
+	assert.Equal(t, []string{"Major", "Critical", "None"}, report.Priorities)
+	assert.Equal(t, []string{"Bug", "Story", "Task"}, report.IssueTypes)
+
+	alice := report.Members[0]
+	assert.Equal(t, 4, alice.Open)
+	assert.Equal(t, 2, alice.WIP)
+	assert.Equal(t, "1d 4h", alice.RemainingEstimate)
+	assert.Equal(t, map[string]int{"Major": 3, "Critical": 1}, alice.ByPriority)
+	assert.Equal(t, map[string]int{"Bug": 2, "Story": 2}, alice.ByType)
+	assert.Equal(t, []string{WorkloadFlagOpen, WorkloadFlagWIP, WorkloadFlagRemaining}, alice.Flags)
+
+	bob := report.Members[1]
+	assert.Equal(t, 1, bob.Open)
+	assert.Equal(t, map[string]int{"None": 1}, bob.ByPriority)
+	assert.Empty(t, bob.Flags)
+
+	assert.Equal(t, 0, report.Members[2].Open)
+	assert.InDelta(t, 5.0/3, report.Mean.Open, 0.001)
+	assert.InDelta(t, 2.0/3, report.Mean.WIP, 0.001)
+	assert.Equal(t, 1, report.Flagged)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintMarkdown(report))
+	assert.NoError(t, PrintCSV(report))
+}
+
+func TestBuildWorkloadReport_Empty(t *testing.T) {
+	report := BuildWorkloadReport(nil, nil, DefaultWorkloadDeviation)
+	assert.Empty(t, report.Members)
+	assert.Equal(t, 0, report.Flagged)
+	assert.NoError(t, PrintTable(report))
+}
+
+func TestFetchTeamWorkload_Validation(t *testing.T) {
+	_, err := FetchTeamWorkload("https://jira.example.com", "user", "token", "CNF", Team{Name: "empty"}, nil, DefaultWorkloadDeviation)
+	assert.Error(t, err)
+}
+
+func TestFetchTeamWorkload_Pages(t *testing.T) {
+	var queries []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		jql := r.URL.Query().Get("jql")
+		queries = append(queries, jql)
+		keys := []string{"CNF-1", "CNF-2", "CNF-3"}
+		if strings.Contains(jql, `assignee = "bob"`) {
+			keys = []string{"CNF-4"}
+		}
+
+		// Serve two issues per page
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		var issues []map[string]interface{}
+		for i := startAt; i < len(keys) && i < startAt+2; i++ {
+			issues = append(issues, map[string]interface{}{"key": keys[i], "fields": map[string]interface{}{
+				"status":   map[string]string{"name": "In Progress"},
+				"priority": map[string]string{"name": "Major"},
+			}})
+		}
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": startAt, "maxResults": 2, "total": len(keys), "issues": issues,
+		})
+	}))
+	defer server.Close()
+
+	team := Team{Name: "cnf", Members: []string{"alice", "bob"}}
+	report, err := FetchTeamWorkload(server.URL, "user", "token", "CNF", team, nil, DefaultWorkloadDeviation)
+	assert.NoError(t, err)
+	assert.Equal(t, "cnf", report.Team)
+	assert.Equal(t, 3, report.Members[0].Open)
+	assert.Equal(t, 1, report.Members[1].Open)
+
+	assert.Len(t, queries, 3)
+	for _, jql := range queries {
+		assert.Contains(t, jql, "resolution = Unresolved")
+	}
+}
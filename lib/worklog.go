@@ -0,0 +1,251 @@
+package lib
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// FetchIssueWorklogs retrieves all work log entries for a specific issue, following pagination
+func FetchIssueWorklogs(client *jira.Client, baseURL, issueKey, apikey string) ([]Worklog, error) {
+	httpClient := getHTTPClient(apikey)
+
+	var worklogs []Worklog
+	startAt := 0
+	for {
+		url := fmt.Sprintf("%s/rest/api/2/issue/%s/worklog?startAt=%d", baseURL, issueKey, startAt)
+
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Accept", "application/json")
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch worklogs: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("jira API returned status %d: %s",
+				resp.StatusCode, string(bodyBytes))
+		}
+
+		var response struct {
+			StartAt    int `json:"startAt"`
+			MaxResults int `json:"maxResults"`
+			Total      int `json:"total"`
+			Worklogs   []struct {
+				ID               string     `json:"id"`
+				Author           *jira.User `json:"author"`
+				Comment          string     `json:"comment"`
+				Started          string     `json:"started"`
+				TimeSpent        string     `json:"timeSpent"`
+				TimeSpentSeconds int        `json:"timeSpentSeconds"`
+			} `json:"worklogs"`
+		}
+		err = json.NewDecoder(resp.Body).Decode(&response)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to decode worklog response: %w", err)
+		}
+
+		for _, w := range response.Worklogs {
+			started, _ := parseJiraTime(w.Started)
+			worklogs = append(worklogs, Worklog{
+				ID:               w.ID,
+				IssueKey:         issueKey,
+				Author:           convertJiraUser(w.Author),
+				Comment:          w.Comment,
+				Started:          started,
+				TimeSpent:        w.TimeSpent,
+				TimeSpentSeconds: w.TimeSpentSeconds,
+			})
+		}
+
+		startAt += len(response.Worklogs)
+		if len(response.Worklogs) == 0 || startAt >= response.Total {
+			return worklogs, nil
+		}
+	}
+}
+
+// userMatches reports whether a Jira user matches a username, email, key or display name
+func userMatches(user *User, name string) bool {
+	if user == nil {
+		return false
+	}
+	for _, candidate := range []string{user.Name, user.EmailAddress, user.Key, user.AccountID, user.DisplayName} {
+		if candidate != "" && strings.EqualFold(candidate, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// TimesheetEntry totals the time one user logged on one issue on one day
+type TimesheetEntry struct {
+	User     string  `json:"user" yaml:"user"`
+	Date     string  `json:"date" yaml:"date"`
+	IssueKey string  `json:"issueKey" yaml:"issueKey"`
+	Summary  string  `json:"summary" yaml:"summary"`
+	Seconds  int     `json:"seconds" yaml:"seconds"`
+	Hours    float64 `json:"hours" yaml:"hours"`
+}
+
+// TimesheetUserTotal totals the time a user logged across the date range
+type TimesheetUserTotal struct {
+	User    string  `json:"user" yaml:"user"`
+	Seconds int     `json:"seconds" yaml:"seconds"`
+	Hours   float64 `json:"hours" yaml:"hours"`
+}
+
+// TimesheetReport totals logged work per user, per day, per issue
+type TimesheetReport struct {
+	DateRange  string               `json:"dateRange" yaml:"dateRange"`
+	Entries    []TimesheetEntry     `json:"entries" yaml:"entries"`
+	UserTotals []TimesheetUserTotal `json:"userTotals" yaml:"userTotals"`
+	TotalHours float64              `json:"totalHours" yaml:"totalHours"`
+}
+
+// BuildTimesheetReport aggregates work logs by user, day and issue.
+// Only work logs started between startDate and endDate (YYYY-MM-DD, inclusive) and
+// authored by one of users are counted. summaries maps issue keys to summaries.
+func BuildTimesheetReport(users []string, startDate, endDate string, worklogs []Worklog, summaries map[string]string) *TimesheetReport {
+	type entryKey struct{ user, date, issue string }
+	seconds := map[entryKey]int{}
+	userSeconds := map[string]int{}
+
+	for _, w := range worklogs {
+		date := w.Started.In(GetTimezone()).Format("2006-01-02")
+		if date < startDate || date > endDate {
+			continue
+		}
+		for _, user := range users {
+			if userMatches(w.Author, user) {
+				seconds[entryKey{user, date, w.IssueKey}] += w.TimeSpentSeconds
+				userSeconds[user] += w.TimeSpentSeconds
+				break
+			}
+		}
+	}
+
+	report := &TimesheetReport{DateRange: fmt.Sprintf("%s to %s", startDate, endDate)}
+	for k, s := range seconds {
+		report.Entries = append(report.Entries, TimesheetEntry{
+			User:     k.user,
+			Date:     k.date,
+			IssueKey: k.issue,
+			Summary:  summaries[k.issue],
+			Seconds:  s,
+			Hours:    secondsToHours(s),
+		})
+	}
+	sort.Slice(report.Entries, func(i, j int) bool {
+		a, b := report.Entries[i], report.Entries[j]
+		if a.User != b.User {
+			return a.User < b.User
+		}
+		if a.Date != b.Date {
+			return a.Date < b.Date
+		}
+		return a.IssueKey < b.IssueKey
+	})
+
+	total := 0
+	for _, user := range users {
+		report.UserTotals = append(report.UserTotals, TimesheetUserTotal{
+			User:    user,
+			Seconds: userSeconds[user],
+			Hours:   secondsToHours(userSeconds[user]),
+		})
+		total += userSeconds[user]
+	}
+	report.TotalHours = secondsToHours(total)
+
+	return report
+}
+
+// secondsToHours converts seconds to hours rounded to two decimal places
+func secondsToHours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+// FetchTimesheet fetches the work logged by users between startDate and endDate
+// (any form accepted by ParseDateRange, widened to whole days) and aggregates it per
+// user, per day, per issue.
+func FetchTimesheet(jiraURL, apikey string, users []string, startDate, endDate string, verbose bool) (*TimesheetReport, error) {
+	if jiraURL == "" || apikey == "" || len(users) == 0 || startDate == "" || endDate == "" {
+		return nil, fmt.Errorf("jiraURL, apikey, users, startDate, and endDate must be provided")
+	}
+
+	// Work logs are totaled per day, so the range is widened to whole days
+	dateRange, err := ParseDateRange(startDate, endDate, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	startDate, endDate = dateRange.FirstDay(), dateRange.LastDay()
+
+	client, err := NewJiraClient(jiraURL, apikey)
+	if err != nil {
+		return nil, fmt.Errorf("creating Jira client: %w", err)
+	}
+
+	quoted := make([]string, len(users))
+	for i, user := range users {
+		quoted[i] = fmt.Sprintf("\"%s\"", user)
+	}
+	jql := fmt.Sprintf("worklogAuthor in (%s) AND worklogDate >= \"%s\" AND worklogDate <= \"%s\" ORDER BY key ASC",
+		strings.Join(quoted, ", "), startDate, endDate)
+
+	issues, err := searchAllIssues(client, jql)
+	if err != nil {
+		return nil, err
+	}
+
+	rl := GetGlobalRateLimiter()
+	summaries := make(map[string]string, len(issues))
+	var worklogs []Worklog
+	for i, issue := range issues {
+		if verbose {
+			fmt.Fprintf(os.Stderr, "Fetching worklogs for %s (%d/%d)...\n", issue.Key, i+1, len(issues))
+		}
+		summaries[issue.Key] = issue.Summary
+		logs, err := FetchIssueWorklogs(client, jiraURL, issue.Key, apikey)
+		if err != nil {
+			return nil, fmt.Errorf("fetching worklogs for %s: %w", issue.Key, err)
+		}
+		worklogs = append(worklogs, logs...)
+
+		if i < len(issues)-1 {
+			rl.Wait()
+		}
+	}
+
+	return BuildTimesheetReport(users, startDate, endDate, worklogs, summaries), nil
+}
+
+func printTimesheetTable(w *tabwriter.Writer, report *TimesheetReport) {
+	fmt.Fprintln(w, "USER\tDATE\tKEY\tHOURS\tSUMMARY")
+	for _, e := range report.Entries {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%.2f\t%s\n", e.User, e.Date, e.IssueKey, e.Hours, truncateSummary(e.Summary))
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "USER\tHOURS")
+	for _, t := range report.UserTotals {
+		fmt.Fprintf(w, "%s\t%.2f\n", t.User, t.Hours)
+	}
+	fmt.Fprintf(w, "Total\t%.2f\n", report.TotalHours)
+}
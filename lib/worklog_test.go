@@ -0,0 +1,103 @@
+package lib
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestFetchIssueWorklogs tests paginating through an issue's worklogs
+func TestFetchIssueWorklogs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/rest/api/2/issue/TEST-123/worklog", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+
+		worklog := func(id string) map[string]interface{} {
+			return map[string]interface{}{
+				"id":               id,
+				"author":           map[string]string{"name": "jdoe", "emailAddress": "jdoe@example.com"},
+				"started":          "2025-01-02T09:00:00.000+0000",
+				"timeSpent":        "1h",
+				"timeSpentSeconds": 3600,
+			}
+		}
+		if r.URL.Query().Get("startAt") == "0" {
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{
+				"startAt": 0, "maxResults": 1, "total": 2,
+				"worklogs": []interface{}{worklog("1")},
+			})
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"startAt": 1, "maxResults": 1, "total": 2,
+			"worklogs": []interface{}{worklog("2")},
+		})
+	}))
+	defer server.Close()
+
+	tokenAuth := jira.BearerAuthTransport{
+		Token: "test-token",
+	}
+	client, err := jira.NewClient(tokenAuth.Client(), server.URL)
+	assert.NoError(t, err)
+
+	worklogs, err := FetchIssueWorklogs(client, server.URL, "TEST-123", "test-token")
+	assert.NoError(t, err)
+	assert.Len(t, worklogs, 2)
+	assert.Equal(t, "TEST-123", worklogs[0].IssueKey)
+	assert.Equal(t, "jdoe@example.com", worklogs[0].Author.EmailAddress)
+	assert.Equal(t, 3600, worklogs[1].TimeSpentSeconds)
+	assert.Equal(t, 2, worklogs[1].Started.Day())
+}
+
+// TestFetchIssueWorklogsError tests error handling for worklogs
+func TestFetchIssueWorklogsError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	worklogs, err := FetchIssueWorklogs(nil, server.URL, "TEST-999", "test-token")
+	assert.Error(t, err)
+	assert.Nil(t, worklogs)
+	assert.Contains(t, err.Error(), "404")
+}
+
+func TestBuildTimesheetReport(t *testing.T) {
+	jdoe := &User{Name: "jdoe", EmailAddress: "jdoe@example.com"}
+	other := &User{Name: "other", EmailAddress: "other@example.com"}
+	at := func(d, h int) time.Time { return time.Date(2025, 1, d, h, 0, 0, 0, time.UTC) }
+
+	worklogs := []Worklog{
+		{IssueKey: "CNF-1", Author: jdoe, Started: at(2, 9), TimeSpentSeconds: 3600},
+		{IssueKey: "CNF-1", Author: jdoe, Started: at(2, 14), TimeSpentSeconds: 1800},
+		{IssueKey: "CNF-2", Author: jdoe, Started: at(3, 9), TimeSpentSeconds: 7200},
+		{IssueKey: "CNF-2", Author: other, Started: at(3, 9), TimeSpentSeconds: 7200},
+		{IssueKey: "CNF-2", Author: jdoe, Started: at(9, 9), TimeSpentSeconds: 7200},
+	}
+
+	report := BuildTimesheetReport([]string{"jdoe@example.com"}, "2025-01-01", "2025-01-03", worklogs, map[string]string{"CNF-1": "First"})
+	assert.Len(t, report.Entries, 2)
+	assert.Equal(t, TimesheetEntry{User: "jdoe@example.com", Date: "2025-01-02", IssueKey: "CNF-1", Summary: "First", Seconds: 5400, Hours: 1.5}, report.Entries[0])
+	assert.Equal(t, "CNF-2", report.Entries[1].IssueKey)
+	assert.Equal(t, []TimesheetUserTotal{{User: "jdoe@example.com", Seconds: 12600, Hours: 3.5}}, report.UserTotals)
+	assert.Equal(t, 3.5, report.TotalHours)
+
+	assert.NoError(t, PrintTable(report))
+	assert.NoError(t, PrintCSV(report))
+}
+
+func TestFetchTimesheet_InvalidInput(t *testing.T) {
+	report, err := FetchTimesheet("", "", []string{"jdoe"}, "2025-01-01", "2025-01-31", false)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+
+	report, err = FetchTimesheet("https://example.com", "token", []string{"jdoe"}, "01/01/2025", "2025-01-31", false)
+	assert.Error(t, err)
+	assert.Nil(t, report)
+}